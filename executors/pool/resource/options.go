@@ -0,0 +1,142 @@
+package pool
+
+import (
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Option - Configures optional behaviour of the pool.
+// Options are not bound to the resource type, so the same ones serve
+// both Pool and TypedPool.
+// -----------------------------------------------------------------------------
+type Option func(*settings)
+
+// -----------------------------------------------------------------------------
+// settings - Optional behaviour collected from the options.
+// Functions over the resource are kept untyped and checked by the pool.
+// -----------------------------------------------------------------------------
+type settings struct {
+	validate    any
+	reset       any
+	maxIdle     time.Duration
+	maxLifetime time.Duration
+	maxCreating int
+	leakAge     time.Duration
+	retries     int
+	backoff     time.Duration
+	order       Order
+}
+
+// -----------------------------------------------------------------------------
+// Order - Order in which free resources are handed out.
+// -----------------------------------------------------------------------------
+type Order int
+
+const (
+	// FIFO - hands out the resource released the longest time ago.
+	FIFO Order = iota
+
+	// LIFO - hands out the most recently released resource, which keeps
+	// fewer resources hot and lets the idle ones age out.
+	LIFO
+)
+
+// -----------------------------------------------------------------------------
+// WithValidator - Checks every free resource before it is handed out.
+// A resource failing validation is closed and discarded, and another one
+// is acquired instead. Freshly created resources are not validated.
+// T must match the resource type of the pool, or creating it fails
+// with ErrorOptionType.
+// -----------------------------------------------------------------------------
+func WithValidator[T any](validate func(T) bool) Option {
+	return func(config *settings) {
+		config.validate = validate
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithReset - Clears the per-use state of every resource released back
+// to the pool, such as rolling back an open transaction. A resource
+// failing the reset is closed and discarded instead of being reused.
+// T must match the resource type of the pool, or creating it fails
+// with ErrorOptionType.
+// -----------------------------------------------------------------------------
+func WithReset[T any](reset func(T) error) Option {
+	return func(config *settings) {
+		config.reset = reset
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithMaxIdleTime - Closes free resources that stayed idle in the pool
+// longer than the given duration. A background reaper checks the pool
+// periodically, and stops when the pool is closed.
+// -----------------------------------------------------------------------------
+func WithMaxIdleTime(maxIdle time.Duration) Option {
+	return func(config *settings) {
+		config.maxIdle = maxIdle
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithMaxLifetime - Closes resources older than the given duration instead
+// of reusing them, checked when a resource is acquired or released.
+// The pool records the creation time of every resource keyed by the
+// resource itself, so resources must be comparable, such as pointers.
+// Creating the pool fails with ErrorNotComparable for a resource type
+// that is not, and so does Acquire for a created resource that is not.
+// -----------------------------------------------------------------------------
+func WithMaxLifetime(maxLifetime time.Duration) Option {
+	return func(config *settings) {
+		config.maxLifetime = maxLifetime
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithMaxConcurrentCreates - Limits the number of resources created at the
+// same time, so a burst of acquirers on a cold pool does not hammer the
+// backend. Acquirers over the limit wait for a creation to finish or
+// a resource to be released. Zero, the default, means no limit.
+// -----------------------------------------------------------------------------
+func WithMaxConcurrentCreates(n int) Option {
+	return func(config *settings) {
+		config.maxCreating = n
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithLeakTracking - Records the stack and time of every Acquire until the
+// resource is released, so LeakedResources can report the ones held longer
+// than the given age. Tracking is off by default since capturing the stack
+// is costly. Resources are tracked by identity, so they must be comparable,
+// such as pointers, the same way as with WithMaxLifetime.
+// -----------------------------------------------------------------------------
+func WithLeakTracking(age time.Duration) Option {
+	return func(config *settings) {
+		config.leakAge = age
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithRetry - Retries a failing allocator up to the given number of times,
+// waiting the backoff before the first retry and doubling it after each one.
+// Acquire gives up early when its context is done, with an error wrapping
+// both the context error and the last allocator error. Once the retries are
+// exhausted, the returned error wraps the last allocator error.
+// -----------------------------------------------------------------------------
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(config *settings) {
+		config.retries = retries
+		config.backoff = backoff
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WithOrder - Selects the order in which free resources are handed out.
+// The default is FIFO.
+// -----------------------------------------------------------------------------
+func WithOrder(order Order) Option {
+	return func(config *settings) {
+		config.order = order
+	}
+}
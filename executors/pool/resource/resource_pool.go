@@ -1,7 +1,7 @@
 // -----------------------------------------------------------------------------
-// The purpose of the pool package is to show how you can use a buffered channel
-// to pool a set of resources that can be shared and individually used by any
-// number of goroutines. This pattern is useful when you have a static set of
+// The purpose of the pool package is to show how you can pool a set of
+// resources that can be shared and individually used by any number
+// of goroutines. This pattern is useful when you have a static set of
 // resources to share, such as database connections or memory buffers.
 // When a goroutine needs one of these resources from the pool,
 // it can acquire the resource, use it, and then return it to the pool.
@@ -9,99 +9,698 @@
 package pool
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
+	"runtime/debug"
+	"sort"
 	"sync"
+	"time"
 )
 
+// -----------------------------------------------------------------------------
+// TypedPool manages a set of resources of type T safely shared by multiple
+// goroutines. The resources are closed with the function given to NewTyped,
+// so T does not need to implement the io.Closer interface.
+// -----------------------------------------------------------------------------
+type TypedPool[T any] struct {
+	mutex    sync.Mutex
+	idle     []idleResource[T]
+	size     int
+	factory  func() (T, error)
+	close    func(T) error
+	created  int
+	creating int
+	changed  chan struct{}
+	closed   bool
+
+	// Statistics - maintained under the mutex, see Stats.
+	opened  int64
+	retired int64
+	waiters int
+
+	// Options - optional behaviour configured at construction.
+	validate    func(T) bool
+	reset       func(T) error
+	maxIdle     time.Duration
+	maxLifetime time.Duration
+	maxCreating int
+	leakAge     time.Duration
+	retries     int
+	backoff     time.Duration
+	order       Order
+
+	// Lifetime - creation time of every resource, kept with max lifetime.
+	born map[any]time.Time
+
+	// Leaks - acquisition of every borrowed resource, kept with leak tracking.
+	borrowed map[any]LeakInfo
+
+	// Reaper - background eviction of idle resources.
+	done   chan struct{}
+	reaper sync.WaitGroup
+}
+
 // -----------------------------------------------------------------------------
 // Pool manages a set of resources safely shared by multiple goroutines.
 // The resource being managed must implement the io.Closer interface.
 // -----------------------------------------------------------------------------
-type Pool struct {
-	mutex     sync.Mutex
-	resources chan io.Closer
-	factory   func() (io.Closer, error)
-	closed    bool
+type Pool = TypedPool[io.Closer]
+
+// -----------------------------------------------------------------------------
+// idleResource - Free resource along with the time it was released.
+// -----------------------------------------------------------------------------
+type idleResource[T any] struct {
+	resource T
+	since    time.Time
+}
+
+// -----------------------------------------------------------------------------
+// PoolStats - Consistent snapshot of the pool counters.
+// -----------------------------------------------------------------------------
+type PoolStats struct {
+	// Available - free resources waiting in the pool.
+	Available int
+
+	// InUse - resources currently handed out.
+	InUse int
+
+	// Created - resources created over the lifetime of the pool.
+	Created int64
+
+	// Closed - resources closed over the lifetime of the pool.
+	Closed int64
+
+	// Waiters - acquirers currently blocked waiting for a resource.
+	Waiters int
+}
+
+// -----------------------------------------------------------------------------
+// LeakInfo - Borrowed resource along with where and when it was acquired.
+// -----------------------------------------------------------------------------
+type LeakInfo struct {
+	// Resource - the resource not yet released.
+	Resource any
+
+	// Acquired - time the resource was acquired.
+	Acquired time.Time
+
+	// Stack - stack trace of the goroutine that acquired the resource.
+	Stack string
 }
 
 // ErrorPoolClosed - returned when an Acquire returns on a closed pool.
 var ErrorPoolClosed = errors.New("Pool has been closed.")
 
+// ErrorAcquireTimeout - returned when no resource becomes available in time.
+var ErrorAcquireTimeout = errors.New("Acquire timeout received.")
+
+// ErrorOptionType - returned when an option does not match the resource type.
+var ErrorOptionType = errors.New("Option does not match the resource type.")
+
+// ErrorNotComparable - returned when an option keys resources that are not comparable.
+var ErrorNotComparable = errors.New("Resource is not comparable.")
+
 // -----------------------------------------------------------------------------
 // New - Creates a pool that manages resources.
 // A pool requires a function that can allocate a new resource
 // and the size of the pool.
 // -----------------------------------------------------------------------------
-func New(allocator func() (io.Closer, error), size uint) (*Pool, error) {
+func New(allocator func() (io.Closer, error), size uint, options ...Option) (*Pool, error) {
+	return NewTyped(allocator, io.Closer.Close, size, options...)
+}
+
+// -----------------------------------------------------------------------------
+// NewTyped - Creates a pool that manages resources of type T.
+// A pool requires a function that can allocate a new resource,
+// a function that closes a resource and the size of the pool.
+// -----------------------------------------------------------------------------
+func NewTyped[T any](allocator func() (T, error), closer func(T) error, size uint, options ...Option) (*TypedPool[T], error) {
 	if size <= 0 {
 		return nil, errors.New("Pool size is to small.")
 	}
 
-	return &Pool{
-		resources: make(chan io.Closer, size),
-		factory:   allocator,
-	}, nil
+	var config settings
+	for _, option := range options {
+		option(&config)
+	}
+
+	pool := &TypedPool[T]{
+		size:        int(size),
+		factory:     allocator,
+		close:       closer,
+		changed:     make(chan struct{}),
+		done:        make(chan struct{}),
+		maxIdle:     config.maxIdle,
+		maxLifetime: config.maxLifetime,
+		maxCreating: config.maxCreating,
+		leakAge:     config.leakAge,
+		retries:     config.retries,
+		backoff:     config.backoff,
+		order:       config.order,
+	}
+
+	if pool.maxLifetime > 0 {
+		if !reflect.TypeFor[T]().Comparable() {
+			return nil, ErrorNotComparable
+		}
+		pool.born = make(map[any]time.Time)
+	}
+
+	if pool.leakAge > 0 {
+		if !reflect.TypeFor[T]().Comparable() {
+			return nil, ErrorNotComparable
+		}
+		pool.borrowed = make(map[any]LeakInfo)
+	}
+
+	if config.validate != nil {
+		validate, ok := config.validate.(func(T) bool)
+		if !ok {
+			return nil, ErrorOptionType
+		}
+		pool.validate = validate
+	}
+
+	if config.reset != nil {
+		reset, ok := config.reset.(func(T) error)
+		if !ok {
+			return nil, ErrorOptionType
+		}
+		pool.reset = reset
+	}
+
+	if pool.maxIdle > 0 {
+		pool.reaper.Add(1)
+		go pool.reap()
+	}
+
+	return pool, nil
 }
 
 // -----------------------------------------------------------------------------
-// Acquire - Retrieves a resource	from the pool.
+// Acquire - Retrieves a resource from the pool.
+// A new resource is created only while fewer than size resources exist,
+// so the pool never holds more than size resources. Otherwise it waits
+// until a resource is released, capacity frees up or, with a limit on
+// concurrent creates, a creation finishes.
 // -----------------------------------------------------------------------------
-func (pool *Pool) Acquire() (io.Closer, error) {
-	select {
-	// Check for a free resource.
-	case resource, ok := <-pool.resources:
-		if !ok {
-			return nil, ErrorPoolClosed
+func (pool *TypedPool[T]) Acquire() (T, error) {
+	return pool.AcquireContext(context.Background())
+}
+
+// -----------------------------------------------------------------------------
+// AcquireContext - Retrieves a resource from the pool like Acquire, but gives
+// up with the context error once the context is cancelled or its deadline
+// passes before a resource becomes available.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) AcquireContext(ctx context.Context) (T, error) {
+	resource, err := pool.acquire(ctx)
+	if err == nil && pool.borrowed != nil {
+		pool.track(resource)
+	}
+	return resource, err
+}
+
+// -----------------------------------------------------------------------------
+// acquire - Retrieves a resource from the pool, see AcquireContext.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) acquire(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		pool.mutex.Lock()
+		if pool.closed {
+			pool.mutex.Unlock()
+			return zero, ErrorPoolClosed
 		}
-		return resource, nil
 
-	// Provide a new resource since there are none available.
-	default:
-		return pool.factory()
+		// Prefer a free resource over creating a new one.
+		if len(pool.idle) > 0 {
+			resource := pool.take()
+			if pool.expired(resource) {
+				pool.discard(resource)
+				pool.mutex.Unlock()
+				continue
+			}
+			pool.mutex.Unlock()
+
+			if pool.usable(resource) {
+				return resource, nil
+			}
+			continue
+		}
+
+		if pool.reserve() {
+			pool.mutex.Unlock()
+			return pool.create(ctx)
+		}
+
+		changed := pool.changed
+		pool.waiters++
+		pool.mutex.Unlock()
+
+		var err error
+		select {
+		// A resource was released, capacity freed up or a creation
+		// finished, so try again.
+		case <-changed:
+
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		pool.mutex.Lock()
+		pool.waiters--
+		pool.mutex.Unlock()
+
+		if err != nil {
+			return zero, err
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// AcquireWithTimeout - Retrieves a resource from the pool, waiting up to
+// the given duration. Returns ErrorAcquireTimeout if none became available,
+// wrapped along with the allocator error if the timeout cut a retry short.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) AcquireWithTimeout(duration time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	resource, err := pool.AcquireContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		var zero T
+		if err != context.DeadlineExceeded {
+			// Keep the allocator error of a retry cut short by the timeout.
+			return zero, fmt.Errorf("%w: %w", ErrorAcquireTimeout, err)
+		}
+		return zero, ErrorAcquireTimeout
 	}
+	return resource, err
 }
 
 // -----------------------------------------------------------------------------
-// Release - Places a new resource into the pool.
+// InUse - Returns the number of resources currently handed out.
 // -----------------------------------------------------------------------------
-func (pool *Pool) Release(resource io.Closer) {
+func (pool *TypedPool[T]) InUse() int {
 	pool.mutex.Lock()
 	defer pool.mutex.Unlock()
 
-	if pool.closed {
-		resource.Close()
-		return
+	return pool.created - len(pool.idle)
+}
+
+// -----------------------------------------------------------------------------
+// Warmup - Eagerly allocates up to n resources and places them into the pool,
+// so the first callers do not pay the factory latency. Allocation stops
+// early once the pool is at capacity, and at the first factory error,
+// which is returned. Warmup honours the limit on concurrent creates.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) Warmup(n int) error {
+	for c := 0; c < n; c++ {
+		pool.mutex.Lock()
+		for !pool.reserve() {
+			if pool.closed {
+				pool.mutex.Unlock()
+				return ErrorPoolClosed
+			}
+			if pool.created >= pool.size {
+				pool.mutex.Unlock()
+				return nil
+			}
+
+			// Wait for a concurrent creation to finish.
+			changed := pool.changed
+			pool.mutex.Unlock()
+			<-changed
+			pool.mutex.Lock()
+		}
+		pool.mutex.Unlock()
+
+		resource, err := pool.create(context.Background())
+		if err != nil {
+			return err
+		}
+		pool.Release(resource)
 	}
+	return nil
+}
 
-	select {
-	// Attempt to place the new resource on the queue.
-	case pool.resources <- resource:
+// -----------------------------------------------------------------------------
+// Use - Acquires a resource, passes it to fn and releases it afterwards,
+// even if fn panics. Acquire errors are returned before fn is called,
+// otherwise the error of fn is returned.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) Use(fn func(T) error) error {
+	resource, err := pool.Acquire()
+	if err != nil {
+		return err
+	}
+	defer pool.Release(resource)
+
+	return fn(resource)
+}
 
-	// If the queue is already at cap we close the resource.
-	default:
-		resource.Close()
+// -----------------------------------------------------------------------------
+// Stats - Returns a snapshot of the pool counters, taken at a single point
+// in time so the values are consistent with each other.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) Stats() PoolStats {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	return PoolStats{
+		Available: len(pool.idle),
+		InUse:     pool.created - len(pool.idle),
+		Created:   pool.opened,
+		Closed:    pool.retired,
+		Waiters:   pool.waiters,
 	}
 }
 
 // -----------------------------------------------------------------------------
-// Close - Shutdown the pool and close all existing resources.
+// LeakedResources - Returns the resources held longer than the age given
+// to WithLeakTracking, oldest first. Returns nil without leak tracking.
 // -----------------------------------------------------------------------------
-func (pool *Pool) Close() {
+func (pool *TypedPool[T]) LeakedResources() []LeakInfo {
 	pool.mutex.Lock()
 	defer pool.mutex.Unlock()
 
-	if pool.closed {
+	var leaked []LeakInfo
+	cutoff := time.Now().Add(-pool.leakAge)
+	for _, info := range pool.borrowed {
+		if info.Acquired.Before(cutoff) {
+			leaked = append(leaked, info)
+		}
+	}
+
+	sort.Slice(leaked, func(i, j int) bool {
+		return leaked[i].Acquired.Before(leaked[j].Acquired)
+	})
+	return leaked
+}
+
+// -----------------------------------------------------------------------------
+// Release - Places an acquired resource back into the pool.
+// The resource is reset first, outside the mutex since it may be slow.
+// A resource released while none is handed out, such as one the pool
+// did not create, is closed without being counted, so the pool never
+// holds more than size resources.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) Release(resource T) {
+	clean := pool.reset == nil || pool.reset(resource) == nil
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if pool.borrowed != nil && pool.identifiable(resource) {
+		delete(pool.borrowed, resource)
+	}
+
+	// Nothing is handed out, so the resource was not acquired from the pool.
+	if pool.created <= len(pool.idle) {
+		pool.close(resource)
+		return
+	}
+
+	// If the pool is closed, already at cap, the resource is too old
+	// or failed the reset we close the resource.
+	if !clean || pool.closed || len(pool.idle) >= pool.size || pool.expired(resource) {
+		pool.discard(resource)
 		return
+	}
+
+	pool.idle = append(pool.idle, idleResource[T]{resource: resource, since: time.Now()})
+	pool.signal()
+}
+
+// -----------------------------------------------------------------------------
+// Close - Shutdown the pool and close all existing resources.
+// Every resource is closed even if some fail, and the failures are
+// returned joined together.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) Close() error {
+	pool.mutex.Lock()
+
+	if pool.closed {
+		pool.mutex.Unlock()
+		return nil
 	} else {
 		pool.closed = true
 	}
 
-	// Close the channel before we drain the channel of its resources.
-	// If we don't do this, we will have a deadlock.
-	close(pool.resources)
+	// Stop the reaper before we drain the pool of its resources.
+	close(pool.done)
+
+	var errs []error
+	for _, idle := range pool.idle {
+		if err := pool.discard(idle.resource); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	pool.idle = nil
+
+	// Wake up the waiters so they notice the pool has been closed.
+	pool.signal()
+	pool.mutex.Unlock()
+
+	// Waited without the mutex, since the reaper may be evicting meanwhile.
+	pool.reaper.Wait()
+
+	return errors.Join(errs...)
+}
+
+// -----------------------------------------------------------------------------
+// track - Records where and when a resource was acquired.
+// The stack is captured before taking the mutex.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) track(resource T) {
+	info := LeakInfo{Resource: resource, Acquired: time.Now(), Stack: string(debug.Stack())}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.borrowed[resource] = info
+}
+
+// -----------------------------------------------------------------------------
+// usable - Validates a free resource, discarding it if it is no longer usable.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) usable(resource T) bool {
+	if pool.validate == nil || pool.validate(resource) {
+		return true
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.discard(resource)
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// take - Removes the next free resource from the pool, the most recently
+// released one for LIFO order and the oldest one otherwise.
+// Must be called with the mutex held and at least one free resource.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) take() T {
+	if pool.order == LIFO {
+		last := len(pool.idle) - 1
+		resource := pool.idle[last].resource
+		pool.idle[last] = idleResource[T]{}
+		pool.idle = pool.idle[:last]
+		return resource
+	}
+	return pool.shift()
+}
+
+// -----------------------------------------------------------------------------
+// shift - Removes the oldest free resource from the pool.
+// Must be called with the mutex held and at least one free resource.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) shift() T {
+	resource := pool.idle[0].resource
+	pool.idle[0] = idleResource[T]{}
+	pool.idle = pool.idle[1:]
+	return resource
+}
+
+// -----------------------------------------------------------------------------
+// reserve - Reserves capacity for a new resource, unless the pool is full,
+// closed or already at the limit of concurrent creates.
+// Must be called with the mutex held.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) reserve() bool {
+	if pool.closed || pool.created >= pool.size {
+		return false
+	}
+	if pool.maxCreating > 0 && pool.creating >= pool.maxCreating {
+		return false
+	}
+
+	pool.created++
+	pool.creating++
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// create - Allocates a resource for reserved capacity, giving the capacity
+// back if the allocation fails.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) create(ctx context.Context) (T, error) {
+	resource, err := pool.allocate(ctx)
+	if err == nil && !pool.identifiable(resource) {
+		pool.close(resource)
+		err = ErrorNotComparable
+	}
+	if err != nil {
+		pool.mutex.Lock()
+		pool.created--
+		pool.creating--
+		pool.signal()
+		pool.mutex.Unlock()
+
+		var zero T
+		return zero, err
+	}
+
+	pool.mutex.Lock()
+	pool.creating--
+	if pool.maxCreating > 0 {
+		// Wake up the waiters held back by the limit on concurrent creates.
+		pool.signal()
+	}
+	pool.opened++
+	if pool.born != nil {
+		pool.born[resource] = time.Now()
+	}
+	pool.mutex.Unlock()
+
+	return resource, nil
+}
+
+// -----------------------------------------------------------------------------
+// allocate - Calls the allocator, retrying with exponential backoff
+// if configured.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) allocate(ctx context.Context) (T, error) {
+	resource, err := pool.factory()
+	if err == nil || pool.retries <= 0 {
+		return resource, err
+	}
+
+	delay := pool.backoff
+	for retry := 0; retry < pool.retries; retry++ {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+
+		case <-ctx.Done():
+			timer.Stop()
+			return resource, fmt.Errorf("Allocation failed after %d attempts: %w", retry+1, errors.Join(ctx.Err(), err))
+		}
+
+		if resource, err = pool.factory(); err == nil {
+			return resource, nil
+		}
+		delay *= 2
+	}
+	return resource, fmt.Errorf("Allocation failed after %d attempts: %w", pool.retries+1, err)
+}
+
+// -----------------------------------------------------------------------------
+// discard - Closes a resource and frees its capacity.
+// Returns the error of closing the resource.
+// Must be called with the mutex held.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) discard(resource T) error {
+	if pool.born != nil && pool.identifiable(resource) {
+		delete(pool.born, resource)
+	}
+
+	err := pool.close(resource)
+	pool.created--
+	pool.retired++
+	pool.signal()
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// expired - Verifies if the resource outlived the max lifetime.
+// Must be called with the mutex held.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) expired(resource T) bool {
+	if pool.born == nil {
+		return false
+	}
+	if !pool.identifiable(resource) {
+		return true
+	}
+
+	born, ok := pool.born[resource]
+	return !ok || time.Since(born) > pool.maxLifetime
+}
+
+// -----------------------------------------------------------------------------
+// identifiable - Verifies if the resource can key the maps of the pool.
+// An interface resource type is comparable, yet its dynamic value may not be,
+// which would panic on the map access while the mutex is held.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) identifiable(resource T) bool {
+	if pool.born == nil && pool.borrowed == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(any(resource))
+	return !value.IsValid() || value.Comparable()
+}
+
+// -----------------------------------------------------------------------------
+// signal - Wakes up the waiters after a release or freed capacity.
+// Must be called with the mutex held.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) signal() {
+	close(pool.changed)
+	pool.changed = make(chan struct{})
+}
+
+// -----------------------------------------------------------------------------
+// reap - Periodically closes the resources idle longer than allowed,
+// until the pool is closed.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) reap() {
+	defer pool.reaper.Done()
+
+	ticker := time.NewTicker(max(pool.maxIdle/2, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pool.evict()
+
+		case <-pool.done:
+			return
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// evict - Closes the resources idle longer than allowed.
+// Free resources are kept in release order, so the stale ones come first.
+// -----------------------------------------------------------------------------
+func (pool *TypedPool[T]) evict() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
 
-	for resource := range pool.resources {
-		resource.Close()
+	cutoff := time.Now().Add(-pool.maxIdle)
+	for len(pool.idle) > 0 && pool.idle[0].since.Before(cutoff) {
+		pool.discard(pool.shift())
 	}
 }
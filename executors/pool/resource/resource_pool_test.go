@@ -0,0 +1,660 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// resource - Test resource remembering whether it has been closed.
+// -----------------------------------------------------------------------------
+type resource struct {
+	id     int64
+	closed atomic.Bool
+}
+
+func (res *resource) Close() error {
+	res.closed.Store(true)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// allocator - Returns a factory of test resources counting its calls.
+// -----------------------------------------------------------------------------
+func allocator(calls *atomic.Int64) func() (io.Closer, error) {
+	return func() (io.Closer, error) {
+		return &resource{id: calls.Add(1)}, nil
+	}
+}
+
+// -----------------------------------------------------------------------------
+// failing - Returns a factory failing the given number of times first.
+// -----------------------------------------------------------------------------
+func failing(failures int64, calls *atomic.Int64) func() (io.Closer, error) {
+	return func() (io.Closer, error) {
+		if calls.Add(1) <= failures {
+			return nil, errFactory
+		}
+		return &resource{}, nil
+	}
+}
+
+var errFactory = errors.New("factory failed")
+
+// -----------------------------------------------------------------------------
+// closer - Test resource of a non-comparable dynamic type.
+// -----------------------------------------------------------------------------
+type closer []byte
+
+func (closer) Close() error {
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// TestNewRejectsInvalidConfiguration - A pool is not created with a zero size
+// or mistyped options.
+// -----------------------------------------------------------------------------
+func TestNewRejectsInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    uint
+		options []Option
+		want    error
+	}{
+		{"zero size", 0, nil, nil},
+		{"validator type", 1, []Option{WithValidator(func(int) bool { return true })}, ErrorOptionType},
+		{"reset type", 1, []Option{WithReset(func(int) error { return nil })}, ErrorOptionType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int64
+			pool, err := New(allocator(&calls), test.size, test.options...)
+			if err == nil || pool != nil {
+				t.Fatalf("expected an error, got pool %v", pool)
+			}
+			if test.want != nil && err != test.want {
+				t.Fatalf("got %v, expected %v", err, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestTypedPool - Resources of any type are pooled and closed by the closer.
+// -----------------------------------------------------------------------------
+func TestTypedPool(t *testing.T) {
+	var closed atomic.Int64
+	pool, err := NewTyped(func() (*bytes.Buffer, error) { return new(bytes.Buffer), nil },
+		func(*bytes.Buffer) error { closed.Add(1); return nil }, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer, _ := pool.Acquire()
+	buffer.WriteString("pooled")
+	pool.Release(buffer)
+
+	if again, _ := pool.Acquire(); again != buffer {
+		t.Fatal("the released buffer was not reused")
+	}
+	pool.Release(buffer)
+
+	pool.Close()
+	if closed.Load() != 1 {
+		t.Fatalf("got %d closed buffers, expected 1", closed.Load())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestNonComparableResources - Options keying resources reject non-comparable
+// ones with an error instead of panicking while holding the mutex.
+// -----------------------------------------------------------------------------
+func TestNonComparableResources(t *testing.T) {
+	options := map[string]Option{
+		"max lifetime":  WithMaxLifetime(time.Hour),
+		"leak tracking": WithLeakTracking(time.Hour),
+	}
+
+	for name, option := range options {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTyped(func() ([]byte, error) { return nil, nil }, func([]byte) error { return nil }, 1, option)
+			if err != ErrorNotComparable {
+				t.Fatalf("got %v, expected ErrorNotComparable", err)
+			}
+
+			pool, err := New(func() (io.Closer, error) { return closer{1}, nil }, 1, option)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pool.Close()
+
+			if _, err := pool.Acquire(); err != ErrorNotComparable {
+				t.Fatalf("got %v, expected ErrorNotComparable", err)
+			}
+
+			// The capacity was given back and the pool is not deadlocked.
+			if stats := pool.Stats(); stats.InUse != 0 {
+				t.Fatalf("got %d resources in use, expected 0", stats.InUse)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestAcquireBlocks - Acquire waits for a release instead of creating more
+// than size resources.
+// -----------------------------------------------------------------------------
+func TestAcquireBlocks(t *testing.T) {
+	var calls atomic.Int64
+	pool, err := New(allocator(&calls), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	held, _ := pool.Acquire()
+
+	acquired := make(chan io.Closer)
+	go func() {
+		resource, _ := pool.Acquire()
+		acquired <- resource
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("a resource was acquired past the pool size")
+
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pool.Release(held)
+
+	select {
+	case resource := <-acquired:
+		if resource != held {
+			t.Fatal("the released resource was not handed out")
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("blocked acquirer was not woken up by Release")
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("got %d factory calls, expected 1", calls.Load())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestReleaseForeignResource - Resources the pool did not hand out are closed
+// and do not let the pool grow past its size.
+// -----------------------------------------------------------------------------
+func TestReleaseForeignResource(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 1)
+	defer pool.Close()
+
+	foreign := &resource{}
+	pool.Release(foreign)
+	pool.Release(&resource{})
+
+	if stats := pool.Stats(); stats.InUse != 0 || stats.Available != 0 || !foreign.closed.Load() {
+		t.Fatalf("got %+v, expected the foreign resources to be closed", stats)
+	}
+
+	pool.Acquire()
+	if _, err := pool.AcquireWithTimeout(10 * time.Millisecond); err != ErrorAcquireTimeout {
+		t.Fatalf("got %v, expected the pool to stay bounded", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestInUse - Only the resources handed out are counted as in use.
+// -----------------------------------------------------------------------------
+func TestInUse(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 2)
+	defer pool.Close()
+
+	first, _ := pool.Acquire()
+	pool.Acquire()
+	if pool.InUse() != 2 {
+		t.Fatalf("got %d resources in use, expected 2", pool.InUse())
+	}
+
+	pool.Release(first)
+	if pool.InUse() != 1 {
+		t.Fatalf("got %d resources in use, expected 1", pool.InUse())
+	}
+
+	pool.Acquire()
+	if pool.InUse() != 2 || calls.Load() != 2 {
+		t.Fatalf("got %d resources in use from %d factory calls", pool.InUse(), calls.Load())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestAcquireContextCancelled - Acquire gives up once the context is cancelled.
+// -----------------------------------------------------------------------------
+func TestAcquireContextCancelled(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 1)
+	defer pool.Close()
+
+	pool.Acquire()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := pool.AcquireContext(ctx); err != context.Canceled {
+		t.Fatalf("got %v, expected context.Canceled", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestAcquireWithTimeout - Acquire gives up once the timeout fires, unless
+// a resource is released in time.
+// -----------------------------------------------------------------------------
+func TestAcquireWithTimeout(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 1)
+	defer pool.Close()
+
+	held, _ := pool.Acquire()
+
+	if _, err := pool.AcquireWithTimeout(10 * time.Millisecond); err != ErrorAcquireTimeout {
+		t.Fatalf("got %v, expected ErrorAcquireTimeout", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.Release(held)
+	}()
+
+	if resource, err := pool.AcquireWithTimeout(time.Second); err != nil || resource != held {
+		t.Fatalf("got %v, expected the released resource", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestCloseWakesWaiters - Blocked acquirers get ErrorPoolClosed on Close.
+// -----------------------------------------------------------------------------
+func TestCloseWakesWaiters(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 1)
+
+	held, _ := pool.Acquire()
+
+	result := make(chan error)
+	go func() {
+		_, err := pool.Acquire()
+		result <- err
+	}()
+
+	// Let the acquirer block before closing.
+	time.Sleep(10 * time.Millisecond)
+	pool.Close()
+
+	select {
+	case err := <-result:
+		if err != ErrorPoolClosed {
+			t.Fatalf("got %v, expected ErrorPoolClosed", err)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken up by Close")
+	}
+
+	// Released after Close, the resource is closed instead of kept.
+	pool.Release(held)
+	if !held.(*resource).closed.Load() {
+		t.Fatal("resource released after Close was not closed")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestCloseReportsErrors - Close failures are joined, and Close is idempotent.
+// -----------------------------------------------------------------------------
+func TestCloseReportsErrors(t *testing.T) {
+	failure := errors.New("close failed")
+	pool, _ := NewTyped(func() (*resource, error) { return &resource{}, nil },
+		func(*resource) error { return failure }, 3)
+
+	pool.Warmup(3)
+
+	err := pool.Close()
+	if !errors.Is(err, failure) {
+		t.Fatalf("got %v, expected the close failure", err)
+	}
+	if stats := pool.Stats(); stats.Closed != 3 {
+		t.Fatalf("got %d closed resources, expected 3", stats.Closed)
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatalf("second Close returned %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestValidatorDiscardsResources - A resource failing validation is not
+// handed out.
+// -----------------------------------------------------------------------------
+func TestValidatorDiscardsResources(t *testing.T) {
+	var calls atomic.Int64
+	pool, err := New(allocator(&calls), 1, WithValidator(func(closer io.Closer) bool {
+		return closer.(*resource).id > 1
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	first, _ := pool.Acquire()
+	pool.Release(first)
+
+	second, _ := pool.Acquire()
+	if second == first || !first.(*resource).closed.Load() {
+		t.Fatal("invalid resource was not discarded")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestResetDiscardsResources - A resource failing the reset is not reused.
+// -----------------------------------------------------------------------------
+func TestResetDiscardsResources(t *testing.T) {
+	var calls atomic.Int64
+	var resets atomic.Int64
+	pool, err := New(allocator(&calls), 1, WithReset(func(io.Closer) error {
+		if resets.Add(1) == 2 {
+			return errors.New("reset failed")
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	first, _ := pool.Acquire()
+	pool.Release(first)
+	if stats := pool.Stats(); stats.Available != 1 {
+		t.Fatalf("got %d available resources, expected 1", stats.Available)
+	}
+
+	again, _ := pool.Acquire()
+	pool.Release(again)
+	if stats := pool.Stats(); stats.Available != 0 || !first.(*resource).closed.Load() {
+		t.Fatal("resource failing the reset was not discarded")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestEviction - Stale resources are closed instead of being reused.
+// -----------------------------------------------------------------------------
+func TestEviction(t *testing.T) {
+	tests := []struct {
+		name   string
+		option Option
+	}{
+		{"max idle time", WithMaxIdleTime(5 * time.Millisecond)},
+		{"max lifetime", WithMaxLifetime(5 * time.Millisecond)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int64
+			pool, err := New(allocator(&calls), 1, test.option)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pool.Close()
+
+			first, _ := pool.Acquire()
+			time.Sleep(20 * time.Millisecond)
+			pool.Release(first)
+			time.Sleep(20 * time.Millisecond)
+
+			second, _ := pool.Acquire()
+			if second == first || !first.(*resource).closed.Load() {
+				t.Fatal("stale resource was reused")
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestStats - The snapshot reflects the resources created, closed and held.
+// -----------------------------------------------------------------------------
+func TestStats(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 2)
+
+	first, _ := pool.Acquire()
+	pool.Acquire()
+	pool.Release(first)
+
+	want := PoolStats{Available: 1, InUse: 1, Created: 2}
+	if stats := pool.Stats(); stats != want {
+		t.Fatalf("got %+v, expected %+v", stats, want)
+	}
+
+	pool.Close()
+
+	want = PoolStats{InUse: 1, Created: 2, Closed: 1}
+	if stats := pool.Stats(); stats != want {
+		t.Fatalf("got %+v after Close, expected %+v", stats, want)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestUse - The resource is released after fn, even if fn panics.
+// -----------------------------------------------------------------------------
+func TestUse(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 1)
+	defer pool.Close()
+
+	failure := errors.New("use failed")
+	if err := pool.Use(func(io.Closer) error { return failure }); err != failure {
+		t.Fatalf("got %v, expected the error of fn", err)
+	}
+
+	func() {
+		defer func() {
+			recover()
+		}()
+		pool.Use(func(io.Closer) error { panic("boom") })
+	}()
+
+	if pool.InUse() != 0 {
+		t.Fatalf("got %d resources in use, expected 0", pool.InUse())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestWarmup - Warmup allocates resources up to the pool size.
+// -----------------------------------------------------------------------------
+func TestWarmup(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 3)
+
+	if err := pool.Warmup(5); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 3 || pool.InUse() != 0 {
+		t.Fatalf("got %d factory calls and %d resources in use", calls.Load(), pool.InUse())
+	}
+
+	pool.Close()
+	if err := pool.Warmup(1); err != ErrorPoolClosed {
+		t.Fatalf("got %v, expected ErrorPoolClosed", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestOrder - Free resources are handed out in the configured order.
+// -----------------------------------------------------------------------------
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		want  int
+	}{
+		{"fifo", FIFO, 0},
+		{"lifo", LIFO, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int64
+			pool, _ := New(allocator(&calls), 2, WithOrder(test.order))
+			defer pool.Close()
+
+			first, _ := pool.Acquire()
+			second, _ := pool.Acquire()
+			pool.Release(first)
+			pool.Release(second)
+
+			next, _ := pool.Acquire()
+			if next != []io.Closer{first, second}[test.want] {
+				t.Fatal("resource handed out in the wrong order")
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestConcurrentCreatesAreLimited - The factory runs at most the configured
+// number of times concurrently.
+// -----------------------------------------------------------------------------
+func TestConcurrentCreatesAreLimited(t *testing.T) {
+	var creating, peak atomic.Int64
+	pool, _ := New(func() (io.Closer, error) {
+		current := creating.Add(1)
+		defer creating.Add(-1)
+		for previous := peak.Load(); current > previous; previous = peak.Load() {
+			if peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return &resource{}, nil
+	}, 10, WithMaxConcurrentCreates(2))
+	defer pool.Close()
+
+	var acquirers sync.WaitGroup
+	for c := 0; c < 10; c++ {
+		acquirers.Add(1)
+		go func() {
+			defer acquirers.Done()
+			if _, err := pool.Acquire(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	acquirers.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("got %d concurrent creates, expected at most 2", peak.Load())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestLeakedResources - Only resources held past the age are reported.
+// -----------------------------------------------------------------------------
+func TestLeakedResources(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(allocator(&calls), 2, WithLeakTracking(5*time.Millisecond))
+	defer pool.Close()
+
+	leaked, _ := pool.Acquire()
+	released, _ := pool.Acquire()
+	pool.Release(released)
+
+	time.Sleep(10 * time.Millisecond)
+
+	leaks := pool.LeakedResources()
+	if len(leaks) != 1 || leaks[0].Resource != leaked || leaks[0].Stack == "" {
+		t.Fatalf("got leaks %v, expected the unreleased resource", leaks)
+	}
+
+	pool.Release(leaked)
+	if leaks := pool.LeakedResources(); len(leaks) != 0 {
+		t.Fatalf("got leaks %v after release", leaks)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestRetry - A failing factory is retried, wrapping its last error.
+// -----------------------------------------------------------------------------
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int64
+		retries  int
+		calls    int64
+		fails    bool
+	}{
+		{"no retry", 1, 0, 1, true},
+		{"recovers", 2, 3, 3, false},
+		{"exhausted", 10, 2, 3, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int64
+			pool, _ := New(failing(test.failures, &calls), 1, WithRetry(test.retries, time.Millisecond))
+			defer pool.Close()
+
+			_, err := pool.Acquire()
+			if test.fails != (err != nil) {
+				t.Fatalf("got error %v", err)
+			}
+			if test.fails && !errors.Is(err, errFactory) {
+				t.Fatalf("got %v, expected to wrap the factory error", err)
+			}
+			if calls.Load() != test.calls {
+				t.Fatalf("got %d factory calls, expected %d", calls.Load(), test.calls)
+			}
+			if stats := pool.Stats(); test.fails && stats.InUse != 0 {
+				t.Fatal("capacity of the failed allocation was not given back")
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestRetryCancelled - A cancelled backoff still reports the factory error.
+// -----------------------------------------------------------------------------
+func TestRetryCancelled(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(failing(10, &calls), 1, WithRetry(5, time.Second))
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := pool.AcquireContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errFactory) {
+		t.Fatalf("got %v, expected to wrap both the context and factory errors", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestRetryTimeout - A timeout during backoff still reports the factory error.
+// -----------------------------------------------------------------------------
+func TestRetryTimeout(t *testing.T) {
+	var calls atomic.Int64
+	pool, _ := New(failing(10, &calls), 1, WithRetry(5, time.Second))
+	defer pool.Close()
+
+	_, err := pool.AcquireWithTimeout(10 * time.Millisecond)
+	if !errors.Is(err, ErrorAcquireTimeout) || !errors.Is(err, errFactory) {
+		t.Fatalf("got %v, expected to wrap both the timeout and factory errors", err)
+	}
+}
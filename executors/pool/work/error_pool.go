@@ -0,0 +1,104 @@
+package work
+
+import (
+	"fmt"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// FallibleWorker - Interface implemented by work that can fail.
+// -----------------------------------------------------------------------------
+type FallibleWorker interface {
+	Work() error
+}
+
+// -----------------------------------------------------------------------------
+// WorkError - Reports the failure of a submitted work, keeping the work
+// itself so it can be resubmitted.
+// -----------------------------------------------------------------------------
+type WorkError struct {
+	Worker FallibleWorker
+	Err    error
+}
+
+func (err *WorkError) Error() string {
+	return fmt.Sprintf("Work failed: %v", err.Err)
+}
+
+func (err *WorkError) Unwrap() error {
+	return err.Err
+}
+
+// -----------------------------------------------------------------------------
+// ErrorPool - Work pool that delivers the errors of failed work.
+// -----------------------------------------------------------------------------
+type ErrorPool struct {
+	pool   *WorkPool
+	errors chan error
+	once   sync.Once
+}
+
+// -----------------------------------------------------------------------------
+// fallible - Adapts a FallibleWorker to the Worker interface of the work pool.
+// -----------------------------------------------------------------------------
+type fallible struct {
+	worker FallibleWorker
+	errors chan<- error
+}
+
+func (adapter fallible) Work() {
+	defer func() {
+		if value := recover(); value != nil {
+			err := fmt.Errorf("Task panicked: %v", value)
+			adapter.errors <- &WorkError{Worker: adapter.worker, Err: err}
+
+			// Still reported by the pool panic handler.
+			panic(value)
+		}
+	}()
+
+	if err := adapter.worker.Work(); err != nil {
+		adapter.errors <- &WorkError{Worker: adapter.worker, Err: err}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// NewErrorPool - Creates a new error pool that waits to the work
+// to be submitted.
+// -----------------------------------------------------------------------------
+func NewErrorPool(size int, options ...Option) (*ErrorPool, error) {
+	pool, err := New(size, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ErrorPool{
+		pool:   pool,
+		errors: make(chan error),
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Submit - Submits work to the pool.
+// -----------------------------------------------------------------------------
+func (pool *ErrorPool) Submit(worker FallibleWorker) error {
+	return pool.pool.Submit(fallible{worker: worker, errors: pool.errors})
+}
+
+// -----------------------------------------------------------------------------
+// Errors - Channel delivering a *WorkError for each failed or panicked work.
+// It must be drained concurrently with the submissions, since a failing
+// worker blocks until its error is received. Closed once Close returns.
+// -----------------------------------------------------------------------------
+func (pool *ErrorPool) Errors() <-chan error {
+	return pool.errors
+}
+
+// -----------------------------------------------------------------------------
+// Close - Waits for all the work to finish and closes the errors channel.
+// Calling Close more than once is safe.
+// -----------------------------------------------------------------------------
+func (pool *ErrorPool) Close() {
+	pool.pool.Close()
+	pool.once.Do(func() { close(pool.errors) })
+}
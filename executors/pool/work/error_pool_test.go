@@ -0,0 +1,58 @@
+package work
+
+import (
+	"errors"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// fallibleFunc - Adapts a plain function to the FallibleWorker interface.
+// -----------------------------------------------------------------------------
+type fallibleFunc func() error
+
+func (fn fallibleFunc) Work() error {
+	return fn()
+}
+
+// -----------------------------------------------------------------------------
+// TestErrorPoolReportsFailures - Both failing and panicking work is reported
+// on the errors channel along with the work itself.
+// -----------------------------------------------------------------------------
+func TestErrorPoolReportsFailures(t *testing.T) {
+	failure := errors.New("work failed")
+	workers := []fallibleFunc{
+		func() error { return failure },
+		func() error { panic("boom") },
+		func() error { return nil },
+	}
+
+	pool, err := NewErrorPool(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reported := make(chan []error)
+	go func() {
+		var errs []error
+		for err := range pool.Errors() {
+			errs = append(errs, err)
+		}
+		reported <- errs
+	}()
+
+	for _, worker := range workers {
+		pool.Submit(worker)
+	}
+	pool.Close()
+
+	errs := <-reported
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, expected 2", len(errs))
+	}
+	for _, err := range errs {
+		var workError *WorkError
+		if !errors.As(err, &workError) || workError.Worker == nil {
+			t.Fatalf("got %v, expected a *WorkError carrying the work", err)
+		}
+	}
+}
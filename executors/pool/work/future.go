@@ -0,0 +1,61 @@
+package work
+
+import (
+	"fmt"
+)
+
+// -----------------------------------------------------------------------------
+// Future - Handle to the outcome of a single submitted task.
+// -----------------------------------------------------------------------------
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// -----------------------------------------------------------------------------
+// SubmitFuture - Submits the task to the pool and returns a handle to await
+// its outcome. Methods cannot take type parameters, hence the function form.
+// If the submit fails, the future completes with the submit error,
+// and if the task panics, with an error describing the panic.
+// -----------------------------------------------------------------------------
+func SubmitFuture[T any](pool *WorkPool, fn func() (T, error)) *Future[T] {
+	future := &Future[T]{done: make(chan struct{})}
+
+	err := pool.SubmitFunc(func() {
+		defer func() {
+			if value := recover(); value != nil {
+				future.err = fmt.Errorf("Task panicked: %v", value)
+				close(future.done)
+
+				// Still reported by the pool panic handler.
+				panic(value)
+			}
+			close(future.done)
+		}()
+
+		future.value, future.err = fn()
+	})
+	if err != nil {
+		future.err = err
+		close(future.done)
+	}
+
+	return future
+}
+
+// -----------------------------------------------------------------------------
+// Await - Blocks until the task completes and returns its outcome.
+// Safe to call any number of times from multiple goroutines.
+// -----------------------------------------------------------------------------
+func (future *Future[T]) Await() (T, error) {
+	<-future.done
+	return future.value, future.err
+}
+
+// -----------------------------------------------------------------------------
+// Done - Channel closed once the task completes, for use in select.
+// -----------------------------------------------------------------------------
+func (future *Future[T]) Done() <-chan struct{} {
+	return future.done
+}
@@ -0,0 +1,88 @@
+package work
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// ParallelMap - Applies fn to every element using a pool of the given number
+// of workers. Each result is stored at the index of its input, so the output
+// keeps the input order regardless of the order in which work finishes.
+// A worker count below one is treated as one. If fn panics, the first
+// panic is raised again in the caller once the running work finishes.
+// -----------------------------------------------------------------------------
+func ParallelMap[T, U any](in []T, workers int, fn func(T) U) []U {
+	out := make([]U, len(in))
+
+	var once sync.Once
+	var panicked bool
+	var value any
+
+	pool, _ := New(concurrency(workers, len(in)), OnPanic(func(recovered any) {
+		once.Do(func() {
+			panicked = true
+			value = recovered
+		})
+	}))
+	for index := range in {
+		index := index
+		pool.SubmitFunc(func() {
+			out[index] = fn(in[index])
+		})
+	}
+	pool.Close()
+
+	if panicked {
+		panic(value)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// ParallelForEach - Runs fn over every element using a pool of the given
+// number of workers and returns the first error encountered. An error stops
+// any further element from starting, while running ones finish naturally.
+// A panic in fn is reported as an error the same way.
+// A worker count below one is treated as one.
+// -----------------------------------------------------------------------------
+func ParallelForEach[T any](in []T, workers int, fn func(T) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var once sync.Once
+	var first error
+
+	fail := func(err error) {
+		once.Do(func() {
+			first = err
+			cancel()
+		})
+	}
+
+	pool, _ := NewWithContext(ctx, concurrency(workers, len(in)), OnPanic(func(value any) {
+		fail(fmt.Errorf("Task panicked: %v", value))
+	}))
+	for _, value := range in {
+		value := value
+		err := pool.SubmitFunc(func() {
+			if err := fn(value); err != nil {
+				fail(err)
+			}
+		})
+		if err != nil {
+			break
+		}
+	}
+	pool.Close()
+
+	return first
+}
+
+// -----------------------------------------------------------------------------
+// concurrency - bounds the worker count to the range [1, jobs].
+// -----------------------------------------------------------------------------
+func concurrency(workers, jobs int) int {
+	return max(1, min(workers, jobs))
+}
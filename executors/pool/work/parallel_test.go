@@ -0,0 +1,37 @@
+package work
+
+import (
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestParallelMapPanics - A panicking fn is raised again in the caller
+// instead of leaving a zero value in the output.
+// -----------------------------------------------------------------------------
+func TestParallelMapPanics(t *testing.T) {
+	defer func() {
+		if value := recover(); value != "boom" {
+			t.Fatalf("recovered %v, expected boom", value)
+		}
+	}()
+
+	ParallelMap([]int{1, 2, 3}, 2, func(value int) int {
+		if value == 2 {
+			panic("boom")
+		}
+		return value
+	})
+	t.Fatal("ParallelMap returned despite the panic")
+}
+
+// -----------------------------------------------------------------------------
+// TestParallelForEachPanics - A panicking fn is reported as the error.
+// -----------------------------------------------------------------------------
+func TestParallelForEachPanics(t *testing.T) {
+	err := ParallelForEach([]int{1, 2, 3}, 2, func(int) error {
+		panic("boom")
+	})
+	if err == nil {
+		t.Fatal("ParallelForEach succeeded despite the panic")
+	}
+}
@@ -0,0 +1,170 @@
+package work
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// PriorityPool - Provides pool of goroutines that execute submitted work
+// in priority order. Unlike WorkPool, submissions never block: waiting work
+// is kept in a heap, and higher priorities are dispatched first.
+// Work of equal priority is dispatched in submission order.
+// Panicking work is recovered the same way as in WorkPool.
+// -----------------------------------------------------------------------------
+type PriorityPool struct {
+	mutex    sync.Mutex
+	ready    *sync.Cond
+	queue    priorityQueue
+	sequence uint64
+	barrier  sync.WaitGroup
+	closed   bool
+
+	// Hooks - optional callbacks configured through options.
+	onPanic       func(any)
+	onWorkerStart func(workerID int)
+	onWorkerStop  func(workerID int)
+}
+
+// -----------------------------------------------------------------------------
+// NewPriorityPool - Creates a new priority pool that waits to the work
+// to be submitted. It accepts the same options as the work pool.
+// -----------------------------------------------------------------------------
+func NewPriorityPool(size int, options ...Option) (*PriorityPool, error) {
+	if size <= 0 {
+		return nil, ErrorPoolSize
+	}
+
+	// Options configure a WorkPool, so collect the hooks from one.
+	var config WorkPool
+	for _, option := range options {
+		option(&config)
+	}
+
+	pool := PriorityPool{
+		onPanic:       config.onPanic,
+		onWorkerStart: config.onWorkerStart,
+		onWorkerStop:  config.onWorkerStop,
+	}
+	pool.ready = sync.NewCond(&pool.mutex)
+
+	pool.barrier.Add(size)
+
+	for c := 0; c < size; c++ {
+		go pool.work(c)
+	}
+
+	return &pool, nil
+}
+
+// -----------------------------------------------------------------------------
+// Submit - Submits work to the pool with the default zero priority.
+// -----------------------------------------------------------------------------
+func (pool *PriorityPool) Submit(worker Worker) error {
+	return pool.SubmitPriority(worker, 0)
+}
+
+// -----------------------------------------------------------------------------
+// SubmitPriority - Submits work to the pool with the given priority.
+// -----------------------------------------------------------------------------
+func (pool *PriorityPool) SubmitPriority(worker Worker, priority int) error {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if pool.closed {
+		return ErrorPoolClosed
+	}
+
+	pool.sequence++
+	heap.Push(&pool.queue, prioritized{
+		worker:   worker,
+		priority: priority,
+		sequence: pool.sequence,
+	})
+	pool.ready.Signal()
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Close - Waits for all the queued work to finish and the goroutines
+// to shutdown.
+// -----------------------------------------------------------------------------
+func (pool *PriorityPool) Close() {
+	pool.mutex.Lock()
+	pool.closed = true
+	pool.ready.Broadcast()
+	pool.mutex.Unlock()
+
+	pool.barrier.Wait()
+}
+
+// -----------------------------------------------------------------------------
+// work - executes queued work until the pool is closed and drained.
+// -----------------------------------------------------------------------------
+func (pool *PriorityPool) work(id int) {
+	defer pool.barrier.Done()
+
+	if pool.onWorkerStart != nil {
+		pool.onWorkerStart(id)
+	}
+	if pool.onWorkerStop != nil {
+		defer pool.onWorkerStop(id)
+	}
+
+	for {
+		pool.mutex.Lock()
+		for pool.queue.Len() == 0 && !pool.closed {
+			pool.ready.Wait()
+		}
+		if pool.queue.Len() == 0 {
+			pool.mutex.Unlock()
+			return
+		}
+		next := heap.Pop(&pool.queue).(prioritized)
+		pool.mutex.Unlock()
+
+		protect(next.worker.Work, pool.onPanic)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// prioritized - Work waiting in the queue along with its ordering keys.
+// -----------------------------------------------------------------------------
+type prioritized struct {
+	worker   Worker
+	priority int
+	sequence uint64
+}
+
+// -----------------------------------------------------------------------------
+// priorityQueue - Implements heap.Interface over the waiting work.
+// -----------------------------------------------------------------------------
+type priorityQueue []prioritized
+
+func (queue priorityQueue) Len() int {
+	return len(queue)
+}
+
+func (queue priorityQueue) Less(i, j int) bool {
+	if queue[i].priority != queue[j].priority {
+		return queue[i].priority > queue[j].priority
+	}
+	return queue[i].sequence < queue[j].sequence
+}
+
+func (queue priorityQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+}
+
+func (queue *priorityQueue) Push(item any) {
+	*queue = append(*queue, item.(prioritized))
+}
+
+func (queue *priorityQueue) Pop() any {
+	old := *queue
+	item := old[len(old)-1]
+	old[len(old)-1] = prioritized{}
+	*queue = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,26 @@
+package work
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestPriorityPoolRecoversPanic - A panicking work is handed to OnPanic
+// and the worker goroutine keeps executing the remaining work.
+// -----------------------------------------------------------------------------
+func TestPriorityPoolRecoversPanic(t *testing.T) {
+	var panics, executed atomic.Int64
+	pool, err := NewPriorityPool(1, OnPanic(func(any) { panics.Add(1) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool.Submit(workerFunc(func() { panic("boom") }))
+	pool.Submit(workerFunc(func() { executed.Add(1) }))
+	pool.Close()
+
+	if panics.Load() != 1 || executed.Load() != 1 {
+		t.Fatalf("recovered %d panics and executed %d works", panics.Load(), executed.Load())
+	}
+}
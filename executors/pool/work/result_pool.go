@@ -0,0 +1,74 @@
+package work
+
+import (
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Producer - Interface implemented by work that produces a result.
+// -----------------------------------------------------------------------------
+type Producer[R any] interface {
+	Work() R
+}
+
+// -----------------------------------------------------------------------------
+// ResultPool - Work pool that delivers the results of submitted work.
+// -----------------------------------------------------------------------------
+type ResultPool[R any] struct {
+	pool    *WorkPool
+	results chan R
+	once    sync.Once
+}
+
+// -----------------------------------------------------------------------------
+// producer - Adapts a Producer to the Worker interface of the work pool.
+// -----------------------------------------------------------------------------
+type producer[R any] struct {
+	producer Producer[R]
+	results  chan<- R
+}
+
+func (adapter producer[R]) Work() {
+	adapter.results <- adapter.producer.Work()
+}
+
+// -----------------------------------------------------------------------------
+// NewResultPool - Creates a new result pool that waits to the work
+// to be submitted.
+// -----------------------------------------------------------------------------
+func NewResultPool[R any](size int, options ...Option) (*ResultPool[R], error) {
+	pool, err := New(size, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResultPool[R]{
+		pool:    pool,
+		results: make(chan R),
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Submit - Submits work to the pool.
+// -----------------------------------------------------------------------------
+func (pool *ResultPool[R]) Submit(worker Producer[R]) error {
+	return pool.pool.Submit(producer[R]{producer: worker, results: pool.results})
+}
+
+// -----------------------------------------------------------------------------
+// Results - Channel delivering the result of each submitted work.
+// It must be drained concurrently with the submissions, since workers
+// block until their result is received. Closed once Close returns.
+// -----------------------------------------------------------------------------
+func (pool *ResultPool[R]) Results() <-chan R {
+	return pool.results
+}
+
+// -----------------------------------------------------------------------------
+// Close - Waits for all the work to finish and closes the results channel.
+// Calling Close more than once is safe.
+// -----------------------------------------------------------------------------
+func (pool *ResultPool[R]) Close() {
+	pool.pool.Close()
+	pool.once.Do(func() { close(pool.results) })
+}
@@ -0,0 +1,61 @@
+package work
+
+// -----------------------------------------------------------------------------
+// Pool - Work pool applying a single task function to submitted inputs
+// and delivering its outputs, without callers defining Worker types.
+// -----------------------------------------------------------------------------
+type Pool[I, O any] struct {
+	pool *ResultPool[O]
+	task func(I) O
+}
+
+// -----------------------------------------------------------------------------
+// call - Adapts a task function and its input to the Producer interface.
+// -----------------------------------------------------------------------------
+type call[I, O any] struct {
+	task  func(I) O
+	input I
+}
+
+func (adapter call[I, O]) Work() O {
+	return adapter.task(adapter.input)
+}
+
+// -----------------------------------------------------------------------------
+// NewPool - Creates a new typed pool that applies the task to every input.
+// -----------------------------------------------------------------------------
+func NewPool[I, O any](size int, task func(I) O, options ...Option) (*Pool[I, O], error) {
+	pool, err := NewResultPool[O](size, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pool[I, O]{
+		pool: pool,
+		task: task,
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Submit - Submits an input to be processed by the task.
+// -----------------------------------------------------------------------------
+func (pool *Pool[I, O]) Submit(input I) error {
+	return pool.pool.Submit(call[I, O]{task: pool.task, input: input})
+}
+
+// -----------------------------------------------------------------------------
+// Results - Channel delivering the task output for each submitted input.
+// It must be drained concurrently with the submissions.
+// Closed once the pool is closed and drained.
+// -----------------------------------------------------------------------------
+func (pool *Pool[I, O]) Results() <-chan O {
+	return pool.pool.Results()
+}
+
+// -----------------------------------------------------------------------------
+// Close - Waits for all the inputs to be processed and closes the results.
+// Calling Close more than once is safe.
+// -----------------------------------------------------------------------------
+func (pool *Pool[I, O]) Close() {
+	pool.pool.Close()
+}
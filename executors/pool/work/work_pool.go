@@ -14,8 +14,11 @@
 package work
 
 import (
+	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // -----------------------------------------------------------------------------
@@ -25,51 +28,617 @@ type Worker interface {
 	Work()
 }
 
+// -----------------------------------------------------------------------------
+// WorkerWithID - Interface implemented by work that needs to know which
+// worker goroutine executes it, e.g. to shard writes per worker.
+// The ID is the same one passed to the lifecycle hooks.
+// -----------------------------------------------------------------------------
+type WorkerWithID interface {
+	Work(id int)
+}
+
+// -----------------------------------------------------------------------------
+// workerFunc - Adapts a plain function to the Worker interface.
+// -----------------------------------------------------------------------------
+type workerFunc func()
+
+func (fn workerFunc) Work() {
+	fn()
+}
+
+// -----------------------------------------------------------------------------
+// task - Work as handed over to the worker goroutines, which pass their ID.
+// -----------------------------------------------------------------------------
+type task func(id int)
+
+// -----------------------------------------------------------------------------
+// adapt - Turns a Worker into a task that ignores the worker ID.
+// -----------------------------------------------------------------------------
+func adapt(worker Worker) task {
+	return func(int) {
+		worker.Work()
+	}
+}
+
 // -----------------------------------------------------------------------------
 // WokrPool - Provides pool of goroutines that can execute submitted work.
 // -----------------------------------------------------------------------------
 type WorkPool struct {
-	workers chan Worker
-	barrier sync.WaitGroup
+	mutex    sync.Mutex
+	resizing sync.Mutex
+	ctx      context.Context
+	workers  chan task
+	quit     chan chan struct{}
+	done     chan struct{}
+	barrier  sync.WaitGroup
+	senders  sync.WaitGroup
+	resumed  *sync.Cond
+	drained  *sync.Cond
+	inflight int
+	unwatch  func() bool
+	closed   bool
+	paused   bool
+	nextID   int
+
+	// Hooks - optional callbacks configured through options.
+	onPanic       func(any)
+	onWorkerStart func(workerID int)
+	onWorkerStop  func(workerID int)
+
+	// Metrics - updated atomically so they can be read while work flows.
+	size      atomic.Int64
+	active    atomic.Int64
+	completed atomic.Int64
+	waiting   atomic.Int64
+}
+
+// -----------------------------------------------------------------------------
+// Option - Configures optional behaviour of the work pool.
+// -----------------------------------------------------------------------------
+type Option func(*WorkPool)
+
+// -----------------------------------------------------------------------------
+// OnPanic - Registers a handler receiving the value of any panic
+// recovered from a worker. Without it such panics are silently dropped.
+// -----------------------------------------------------------------------------
+func OnPanic(handler func(any)) Option {
+	return func(pool *WorkPool) {
+		pool.onPanic = handler
+	}
+}
+
+// -----------------------------------------------------------------------------
+// OnWorkerStart - Registers a hook invoked once by each worker goroutine
+// when it starts. The worker ID is stable for the goroutine's lifetime.
+// -----------------------------------------------------------------------------
+func OnWorkerStart(hook func(workerID int)) Option {
+	return func(pool *WorkPool) {
+		pool.onWorkerStart = hook
+	}
+}
+
+// -----------------------------------------------------------------------------
+// OnWorkerStop - Registers a hook invoked once by each worker goroutine
+// when it stops. Close waits for the hooks to return.
+// -----------------------------------------------------------------------------
+func OnWorkerStop(hook func(workerID int)) Option {
+	return func(pool *WorkPool) {
+		pool.onWorkerStop = hook
+	}
 }
 
+// ErrorPoolSize - returned when a pool is given a non-positive size.
+var ErrorPoolSize = errors.New("Pool size is to small.")
+
+// ErrorQueueSize - returned when a buffered pool is given a negative queue size.
+var ErrorQueueSize = errors.New("Queue size is negative.")
+
+// ErrorPoolClosed - returned when an operation is issued on a closed pool.
+var ErrorPoolClosed = errors.New("Pool has been closed.")
+
+// ErrorSubmitTimeout - returned when no worker takes the work in time.
+var ErrorSubmitTimeout = errors.New("Submit timeout received.")
+
+// ErrorCloseTimeout - returned when the work does not drain in time on close.
+var ErrorCloseTimeout = errors.New("Close timeout received.")
+
 // -----------------------------------------------------------------------------
 // New - Creates a new worker pool that waits to the work to be submitted.
 // -----------------------------------------------------------------------------
-func New(size int) (*WorkPool, error) {
+func New(size int, options ...Option) (*WorkPool, error) {
+	return NewWithContext(context.Background(), size, options...)
+}
+
+// -----------------------------------------------------------------------------
+// NewWithContext - Creates a new worker pool bound to the context.
+// Once the context is cancelled workers stop pulling new work and Submit
+// returns the context error. Work already running finishes naturally.
+// -----------------------------------------------------------------------------
+func NewWithContext(ctx context.Context, size int, options ...Option) (*WorkPool, error) {
+	return create(ctx, size, 0, options)
+}
+
+// -----------------------------------------------------------------------------
+// NewBuffered - Creates a new worker pool backed by a bounded queue.
+// Submit only blocks once the queue is full, trading the hand over
+// guarantee of the unbuffered pool for smoother bursts. Close still
+// waits for the queued work to be executed.
+// -----------------------------------------------------------------------------
+func NewBuffered(workers, queueSize int, options ...Option) (*WorkPool, error) {
+	if queueSize < 0 {
+		return nil, ErrorQueueSize
+	}
+	return create(context.Background(), workers, queueSize, options)
+}
+
+// -----------------------------------------------------------------------------
+// create - builds the pool around a work channel of the given capacity.
+// -----------------------------------------------------------------------------
+func create(ctx context.Context, size, queueSize int, options []Option) (*WorkPool, error) {
 	if size <= 0 {
-		return nil, errors.New("Pool size is to small.")
+		return nil, ErrorPoolSize
 	}
 
 	pool := WorkPool{
-		workers: make(chan Worker),
+		ctx:     ctx,
+		workers: make(chan task, queueSize),
+		quit:    make(chan chan struct{}),
+		done:    make(chan struct{}),
 	}
+	pool.resumed = sync.NewCond(&pool.mutex)
+	pool.drained = sync.NewCond(&pool.mutex)
 
-	pool.barrier.Add(size)
+	// Paused workers wait on the cond, so wake them up once cancelled.
+	pool.unwatch = context.AfterFunc(ctx, func() {
+		pool.mutex.Lock()
+		defer pool.mutex.Unlock()
 
-	for c := 0; c < size; c++ {
-		go func() {
-			for worker := range pool.workers {
-				worker.Work()
-			}
-			pool.barrier.Done()
-		}()
+		pool.resumed.Broadcast()
+	})
+
+	for _, option := range options {
+		option(&pool)
 	}
 
+	pool.spawn(size)
+
 	return &pool, nil
 }
 
 // -----------------------------------------------------------------------------
 // Submit - Submits work to the pool.
+// Returns ErrorPoolClosed once the pool is closed, or the context error
+// if the pool context has been cancelled.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) Submit(worker Worker) error {
+	return pool.submit(context.Background(), adapt(worker), nil)
+}
+
+// -----------------------------------------------------------------------------
+// SubmitWithID - Submits work that receives the ID of the worker goroutine
+// executing it. A type cannot implement both Work methods, so the ID-aware
+// work has its own submit.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) SubmitWithID(worker WorkerWithID) error {
+	return pool.submit(context.Background(), worker.Work, nil)
+}
+
+// -----------------------------------------------------------------------------
+// SubmitFunc - Submits a plain function as work to the pool.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) SubmitFunc(fn func()) error {
+	return pool.Submit(workerFunc(fn))
+}
+
+// -----------------------------------------------------------------------------
+// SubmitAll - Submits the workers in order, blocking like Submit does.
+// Returns how many were submitted and the error that stopped the batch.
 // -----------------------------------------------------------------------------
-func (pool *WorkPool) Submit(worker Worker) {
-	pool.workers <- worker
+func (pool *WorkPool) SubmitAll(workers []Worker) (int, error) {
+	return pool.SubmitAllContext(context.Background(), workers)
+}
+
+// -----------------------------------------------------------------------------
+// SubmitAllContext - Works like SubmitAll, but aborts the remaining
+// submissions once the given context is cancelled.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) SubmitAllContext(ctx context.Context, workers []Worker) (int, error) {
+	for index, worker := range workers {
+		if err := pool.submit(ctx, adapt(worker), nil); err != nil {
+			return index, err
+		}
+	}
+	return len(workers), nil
+}
+
+// -----------------------------------------------------------------------------
+// TrySubmit - Submits work only if a worker is free to take it right away.
+// Returns false without blocking when every worker is busy.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) TrySubmit(worker Worker) bool {
+	if pool.ctx.Err() != nil {
+		return false
+	}
+
+	if !pool.enter() {
+		return false
+	}
+	defer pool.senders.Done()
+
+	select {
+	case pool.workers <- adapt(worker):
+		return true
+
+	default:
+		pool.finish()
+		return false
+	}
+}
+
+// -----------------------------------------------------------------------------
+// SubmitWithTimeout - Submits work, waiting up to the given duration
+// for a free worker. Returns ErrorSubmitTimeout if none became free.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) SubmitWithTimeout(worker Worker, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	return pool.submit(context.Background(), adapt(worker), timer.C)
+}
+
+// -----------------------------------------------------------------------------
+// Wait - Blocks until all the submitted work has completed, leaving
+// the pool open for further submissions. Work submitted concurrently
+// is waited for as well, so Wait returns once no work is in flight.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) Wait() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for pool.inflight > 0 {
+		pool.drained.Wait()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Pause - Stops the workers from starting new work until Resume.
+// Work already running completes, and submissions block (or queue up in
+// a buffered pool) meanwhile. A worker that took a work right before the
+// pause holds it until Resume. Close resumes a paused pool.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) Pause() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.paused = true
+}
+
+// -----------------------------------------------------------------------------
+// Resume - Lets the paused workers continue with the submitted work.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) Resume() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.paused = false
+	pool.resumed.Broadcast()
+}
+
+// -----------------------------------------------------------------------------
+// Resize - Grows or shrinks the number of worker goroutines.
+// Growing spawns new workers right away. Shrinking signals the surplus
+// workers to exit and blocks until each of them finished its current work.
+// Paused workers do not take the signal, so shrinking a paused pool blocks
+// until Resume, Close or the pool context is cancelled.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) Resize(size int) error {
+	if size <= 0 {
+		return ErrorPoolSize
+	}
+
+	pool.resizing.Lock()
+	defer pool.resizing.Unlock()
+
+	// Spawning is guarded so that no worker starts after Close.
+	pool.mutex.Lock()
+	if pool.closed {
+		pool.mutex.Unlock()
+		return ErrorPoolClosed
+	}
+	if err := pool.ctx.Err(); err != nil {
+		pool.mutex.Unlock()
+		return err
+	}
+	if current := int(pool.size.Load()); size > current {
+		pool.spawn(size - current)
+	}
+	pool.mutex.Unlock()
+
+	for surplus := int(pool.size.Load()) - size; surplus > 0; surplus-- {
+		// Closed by the worker once it has stopped and left the pool size.
+		stopped := make(chan struct{})
+
+		select {
+		case pool.quit <- stopped:
+			<-stopped
+
+		case <-pool.done:
+			return ErrorPoolClosed
+
+		case <-pool.ctx.Done():
+			return pool.ctx.Err()
+		}
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
-// Close - Waits for all the goroutines to shutdown.
+// Close - Stops accepting work and waits for all the goroutines to shutdown.
+// Submitters still blocked waiting for a worker get ErrorPoolClosed.
 // -----------------------------------------------------------------------------
 func (pool *WorkPool) Close() {
-	close(pool.workers)
+	pool.shutdown()
 	pool.barrier.Wait()
 }
+
+// -----------------------------------------------------------------------------
+// CloseWithTimeout - Stops accepting work like Close, but waits at most
+// the given duration for the work in flight to finish.
+// Returns ErrorCloseTimeout if the goroutines did not shutdown in time.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) CloseWithTimeout(duration time.Duration) error {
+	pool.shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		pool.barrier.Wait()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		return nil
+
+	case <-timer.C:
+		return ErrorCloseTimeout
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ActiveWorkers - Returns the number of workers currently executing work.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) ActiveWorkers() int {
+	return int(pool.active.Load())
+}
+
+// -----------------------------------------------------------------------------
+// CompletedCount - Returns the total number of finished works,
+// including the ones that panicked.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) CompletedCount() int64 {
+	return pool.completed.Load()
+}
+
+// -----------------------------------------------------------------------------
+// PendingSubmits - Returns the number of submitters currently blocked
+// waiting for a free worker. A growing number signals saturation.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) PendingSubmits() int {
+	return int(pool.waiting.Load())
+}
+
+// -----------------------------------------------------------------------------
+// PoolSize - Returns the current number of running worker goroutines,
+// which drops to zero once the pool is closed or its context cancelled.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) PoolSize() int {
+	return int(pool.size.Load())
+}
+
+// -----------------------------------------------------------------------------
+// submit - hands the work over to a free worker, giving up when either
+// the pool or the caller context is cancelled, or the timeout fires.
+// A nil timeout never fires.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) submit(ctx context.Context, work task, timeout <-chan time.Time) error {
+	if err := pool.ctx.Err(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !pool.enter() {
+		return ErrorPoolClosed
+	}
+	defer pool.senders.Done()
+
+	pool.waiting.Add(1)
+	defer pool.waiting.Add(-1)
+
+	select {
+	case pool.workers <- work:
+		return nil
+
+	case <-pool.done:
+		pool.finish()
+		return ErrorPoolClosed
+
+	case <-pool.ctx.Done():
+		pool.finish()
+		return pool.ctx.Err()
+
+	case <-ctx.Done():
+		pool.finish()
+		return ctx.Err()
+
+	case <-timeout:
+		pool.finish()
+		return ErrorSubmitTimeout
+	}
+}
+
+// -----------------------------------------------------------------------------
+// shutdown - marks the pool closed and closes the work channel, letting
+// the workers exit once the remaining work is done.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) shutdown() {
+	pool.mutex.Lock()
+	if pool.closed {
+		pool.mutex.Unlock()
+		return
+	}
+	pool.closed = true
+	pool.paused = false
+	pool.resumed.Broadcast()
+	close(pool.done)
+	pool.mutex.Unlock()
+
+	pool.unwatch()
+
+	// No sender may be left when the channel closes, or its send would panic.
+	pool.senders.Wait()
+	close(pool.workers)
+}
+
+// -----------------------------------------------------------------------------
+// enter - registers a sender unless the pool is closed, counting its work
+// as in flight before the hand over so Wait can never miss it.
+// Registered senders must call senders.Done once they stop sending,
+// and finish if the work was not handed over.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) enter() bool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if pool.closed {
+		return false
+	}
+	pool.senders.Add(1)
+	pool.inflight++
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// finish - uncounts a work in flight, waking Wait once none is left.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) finish() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.inflight--
+	if pool.inflight == 0 {
+		pool.drained.Broadcast()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// spawn - starts the given number of worker goroutines.
+// The barrier is raised before the goroutines start so Close waits for them.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) spawn(count int) {
+	pool.barrier.Add(count)
+	pool.size.Add(int64(count))
+
+	for c := 0; c < count; c++ {
+		go pool.work(pool.nextID)
+		pool.nextID++
+	}
+}
+
+// -----------------------------------------------------------------------------
+// work - executes submitted work until the pool is closed, cancelled
+// or the worker is asked to quit.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) work(id int) {
+	defer pool.barrier.Done()
+
+	// Acknowledges a Resize once everything else has been done.
+	var stopped chan struct{}
+	defer func() {
+		if stopped != nil {
+			close(stopped)
+		}
+	}()
+
+	if pool.onWorkerStart != nil {
+		pool.onWorkerStart(id)
+	}
+	if pool.onWorkerStop != nil {
+		defer pool.onWorkerStop(id)
+	}
+
+	// The pool size counts the running workers, however they stop.
+	defer pool.size.Add(-1)
+
+	for {
+		pool.awaitResume()
+
+		// Checked first, since select picks randomly among ready cases.
+		if pool.ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case work, ok := <-pool.workers:
+			if !ok {
+				return
+			}
+			// The pool may have been paused while this worker was idle.
+			pool.awaitResume()
+			pool.run(work, id)
+
+		case stopped = <-pool.quit:
+			return
+
+		case <-pool.ctx.Done():
+			return
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// awaitResume - blocks the calling worker while the pool is paused,
+// unless the pool context is cancelled.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) awaitResume() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for pool.paused && pool.ctx.Err() == nil {
+		pool.resumed.Wait()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// run - executes a single work, recovering from its panic so the worker
+// goroutine survives and the barrier accounting stays correct.
+// -----------------------------------------------------------------------------
+func (pool *WorkPool) run(work task, id int) {
+	pool.active.Add(1)
+	defer func() {
+		pool.active.Add(-1)
+		pool.completed.Add(1)
+		pool.finish()
+	}()
+
+	protect(func() { work(id) }, pool.onPanic)
+}
+
+// -----------------------------------------------------------------------------
+// protect - executes a single work, recovering from its panic and handing
+// the value over to the handler, if any.
+// -----------------------------------------------------------------------------
+func protect(work func(), onPanic func(any)) {
+	defer func() {
+		if value := recover(); value != nil && onPanic != nil {
+			onPanic(value)
+		}
+	}()
+
+	work()
+}
@@ -0,0 +1,156 @@
+package work
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// TestWaitWithConcurrentSubmits - Wait must not crash nor return early
+// while other goroutines keep submitting work.
+// -----------------------------------------------------------------------------
+func TestWaitWithConcurrentSubmits(t *testing.T) {
+	pool, err := New(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	var submitted, executed atomic.Int64
+	var submitters sync.WaitGroup
+	for s := 0; s < 4; s++ {
+		submitters.Add(1)
+		go func() {
+			defer submitters.Done()
+			for c := 0; c < 2000; c++ {
+				if err := pool.SubmitFunc(func() { executed.Add(1) }); err != nil {
+					t.Error(err)
+					return
+				}
+				submitted.Add(1)
+			}
+		}()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		submitters.Wait()
+		close(stopped)
+	}()
+
+	for waiting := true; waiting; {
+		select {
+		case <-stopped:
+			waiting = false
+
+		default:
+			pool.Wait()
+		}
+	}
+	pool.Wait()
+
+	if executed.Load() != submitted.Load() {
+		t.Fatalf("executed %d of %d submitted works", executed.Load(), submitted.Load())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestWaitWithNestedSubmits - Work submitting more work is waited for.
+// -----------------------------------------------------------------------------
+func TestWaitWithNestedSubmits(t *testing.T) {
+	pool, err := NewBuffered(2, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	var executed atomic.Int64
+	for c := 0; c < 8; c++ {
+		err := pool.SubmitFunc(func() {
+			executed.Add(1)
+			if err := pool.SubmitFunc(func() { executed.Add(1) }); err != nil {
+				t.Error(err)
+			}
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pool.Wait()
+
+	if executed.Load() != 16 {
+		t.Fatalf("executed %d works, expected 16", executed.Load())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestCancelWhilePaused - Cancelling the pool context stops paused workers.
+// -----------------------------------------------------------------------------
+func TestCancelWhilePaused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan int, 2)
+	pool, err := NewWithContext(ctx, 2, OnWorkerStop(func(id int) { stopped <- id }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	pool.Pause()
+	cancel()
+
+	for c := 0; c < 2; c++ {
+		select {
+		case <-stopped:
+
+		case <-time.After(time.Second):
+			t.Fatal("paused worker did not stop on cancellation")
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestResizeCancelled - Resize reports the context error of a cancelled pool
+// instead of spawning workers that exit right away.
+// -----------------------------------------------------------------------------
+func TestResizeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool, err := NewWithContext(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	cancel()
+
+	if err := pool.Resize(5); err != context.Canceled {
+		t.Fatalf("got %v, expected context.Canceled", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestPoolSize - The pool size follows Resize and drops to zero on Close.
+// -----------------------------------------------------------------------------
+func TestPoolSize(t *testing.T) {
+	pool, err := New(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.Resize(5); err != nil || pool.PoolSize() != 5 {
+		t.Fatalf("got size %d and error %v, expected 5", pool.PoolSize(), err)
+	}
+	if err := pool.Resize(1); err != nil || pool.PoolSize() != 1 {
+		t.Fatalf("got size %d and error %v, expected 1", pool.PoolSize(), err)
+	}
+
+	pool.Close()
+	if pool.PoolSize() != 0 {
+		t.Fatalf("got size %d after Close, expected 0", pool.PoolSize())
+	}
+}
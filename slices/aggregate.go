@@ -0,0 +1,142 @@
+package slices
+
+import (
+	"cmp"
+)
+
+// -----------------------------------------------------------------------------
+// Number - constraint covering all integer and float kinds.
+// -----------------------------------------------------------------------------
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// -----------------------------------------------------------------------------
+// Min - returns the smallest element according to less.
+// The boolean is false when the slice is empty.
+// -----------------------------------------------------------------------------
+func Min[T any](in []T, less func(a, b T) bool) (T, bool) {
+	var result T
+	if len(in) == 0 {
+		return result, false
+	}
+
+	result = in[0]
+	for _, value := range in[1:] {
+		if less(value, result) {
+			result = value
+		}
+	}
+	return result, true
+}
+
+// -----------------------------------------------------------------------------
+// Max - returns the largest element according to less.
+// The boolean is false when the slice is empty.
+// -----------------------------------------------------------------------------
+func Max[T any](in []T, less func(a, b T) bool) (T, bool) {
+	var result T
+	if len(in) == 0 {
+		return result, false
+	}
+
+	result = in[0]
+	for _, value := range in[1:] {
+		if less(result, value) {
+			result = value
+		}
+	}
+	return result, true
+}
+
+// -----------------------------------------------------------------------------
+// Sum - returns the sum of all elements, or zero for an empty slice.
+// -----------------------------------------------------------------------------
+func Sum[T Number](in []T) T {
+	var result T
+	for _, value := range in {
+		result += value
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// Average - returns the arithmetic mean of all elements.
+// The boolean is false, and the mean zero, when the slice is empty.
+// -----------------------------------------------------------------------------
+func Average[T Number](in []T) (float64, bool) {
+	if len(in) == 0 {
+		return 0, false
+	}
+
+	// Accumulate as float64 so small integer kinds do not overflow.
+	total := 0.0
+	for _, value := range in {
+		total += float64(value)
+	}
+	return total / float64(len(in)), true
+}
+
+// -----------------------------------------------------------------------------
+// Count - returns how many elements satisfy the predicate.
+// -----------------------------------------------------------------------------
+func Count[T any](in []T, pred func(T) bool) int {
+	count := 0
+	for _, value := range in {
+		if pred(value) {
+			count++
+		}
+	}
+	return count
+}
+
+// -----------------------------------------------------------------------------
+// Frequency - returns the number of occurrences of each distinct value.
+// -----------------------------------------------------------------------------
+func Frequency[T comparable](in []T) map[T]int {
+	out := make(map[T]int)
+	for _, value := range in {
+		out[value]++
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// MinBy - returns the element with the smallest extracted key.
+// Ties resolve to the first such element, and the boolean is false
+// when the slice is empty.
+// -----------------------------------------------------------------------------
+func MinBy[T any, K cmp.Ordered](in []T, key func(T) K) (T, bool) {
+	return extremeBy(in, key, func(a, b K) bool { return a < b })
+}
+
+// -----------------------------------------------------------------------------
+// MaxBy - returns the element with the largest extracted key.
+// Ties resolve to the first such element, and the boolean is false
+// when the slice is empty.
+// -----------------------------------------------------------------------------
+func MaxBy[T any, K cmp.Ordered](in []T, key func(T) K) (T, bool) {
+	return extremeBy(in, key, func(a, b K) bool { return a > b })
+}
+
+// -----------------------------------------------------------------------------
+// extremeBy - returns the first element whose key is not beaten by any other,
+// computing the key of each element only once.
+// -----------------------------------------------------------------------------
+func extremeBy[T any, K cmp.Ordered](in []T, key func(T) K, beats func(a, b K) bool) (T, bool) {
+	var result T
+	if len(in) == 0 {
+		return result, false
+	}
+
+	result = in[0]
+	best := key(result)
+	for _, value := range in[1:] {
+		if current := key(value); beats(current, best) {
+			result, best = value, current
+		}
+	}
+	return result, true
+}
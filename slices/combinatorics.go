@@ -0,0 +1,78 @@
+package slices
+
+import (
+	"errors"
+)
+
+// MaxPermutationLength - the longest input accepted by Permutations.
+const MaxPermutationLength = 10
+
+// ErrorTooLarge - returned when an input is too long to enumerate.
+var ErrorTooLarge = errors.New("Input is too large to enumerate.")
+
+// -----------------------------------------------------------------------------
+// Permutations - returns all orderings of the input.
+// The result grows as O(n!), so inputs longer than MaxPermutationLength
+// are rejected with ErrorTooLarge.
+// -----------------------------------------------------------------------------
+func Permutations[T any](in []T) ([][]T, error) {
+	if len(in) > MaxPermutationLength {
+		return nil, ErrorTooLarge
+	}
+
+	out := [][]T{}
+	current := make([]T, 0, len(in))
+	used := make([]bool, len(in))
+
+	var permute func()
+	permute = func() {
+		if len(current) == len(in) {
+			out = append(out, Clone(current))
+			return
+		}
+		for index, value := range in {
+			if used[index] {
+				continue
+			}
+			used[index] = true
+			current = append(current, value)
+			permute()
+			current = current[:len(current)-1]
+			used[index] = false
+		}
+	}
+	permute()
+
+	return out, nil
+}
+
+// -----------------------------------------------------------------------------
+// Combinations - returns all k-element combinations without repeats.
+// Elements within a combination follow the input order. A k larger than
+// the input yields an empty result, and a zero k a single empty combination.
+// -----------------------------------------------------------------------------
+func Combinations[T any](in []T, k int) [][]T {
+	out := [][]T{}
+	if k < 0 || k > len(in) {
+		return out
+	}
+
+	current := make([]T, 0, k)
+
+	var combine func(start int)
+	combine = func(start int) {
+		if len(current) == k {
+			out = append(out, Clone(current))
+			return
+		}
+		// Stop early once too few elements remain to complete a combination.
+		for index := start; index <= len(in)-(k-len(current)); index++ {
+			current = append(current, in[index])
+			combine(index + 1)
+			current = current[:len(current)-1]
+		}
+	}
+	combine(0)
+
+	return out
+}
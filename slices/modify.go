@@ -0,0 +1,107 @@
+package slices
+
+import (
+	"errors"
+)
+
+// ErrorIndexOutOfRange - returned when an index falls outside of the slice.
+var ErrorIndexOutOfRange = errors.New("Index out of range.")
+
+// ErrorInvalidRange - returned when a range ends before it starts.
+var ErrorInvalidRange = errors.New("Range end precedes its start.")
+
+// -----------------------------------------------------------------------------
+// RemoveAt - returns a new slice without the element at the given index.
+// The order of the remaining elements is preserved.
+// -----------------------------------------------------------------------------
+func RemoveAt[T any](in []T, index int) ([]T, error) {
+	if index < 0 || index >= len(in) {
+		return nil, ErrorIndexOutOfRange
+	}
+	return RemoveRange(in, index, index+1)
+}
+
+// -----------------------------------------------------------------------------
+// RemoveRange - returns a new slice without the elements in the half-open
+// range [start, end). An empty range returns an unchanged copy.
+// -----------------------------------------------------------------------------
+func RemoveRange[T any](in []T, start, end int) ([]T, error) {
+	if start < 0 || end > len(in) {
+		return nil, ErrorIndexOutOfRange
+	}
+	if start > end {
+		return nil, ErrorInvalidRange
+	}
+
+	out := make([]T, 0, len(in)-(end-start))
+	out = append(out, in[:start]...)
+	out = append(out, in[end:]...)
+	return out, nil
+}
+
+// -----------------------------------------------------------------------------
+// InsertAt - returns a new slice with the value placed at the given index,
+// shifting the later elements right. Inserting at len(in) appends the value.
+// The input slice is left untouched.
+// -----------------------------------------------------------------------------
+func InsertAt[T any](in []T, index int, value T) ([]T, error) {
+	return InsertSlice(in, index, []T{value})
+}
+
+// -----------------------------------------------------------------------------
+// InsertSlice - returns a new slice with all values spliced in at the given
+// index, shifting the later elements right. Inserting at len(in) appends.
+// The input slice is left untouched.
+// -----------------------------------------------------------------------------
+func InsertSlice[T any](in []T, index int, values []T) ([]T, error) {
+	if index < 0 || index > len(in) {
+		return nil, ErrorIndexOutOfRange
+	}
+
+	out := make([]T, 0, len(in)+len(values))
+	out = append(out, in[:index]...)
+	out = append(out, values...)
+	out = append(out, in[index:]...)
+	return out, nil
+}
+
+// -----------------------------------------------------------------------------
+// DeleteValue - returns a new slice without the first occurrence of value.
+// When the value is not present an unchanged copy is returned.
+// -----------------------------------------------------------------------------
+func DeleteValue[T comparable](in []T, value T) []T {
+	index := FindIndex(in, func(element T) bool { return element == value })
+	if index == INVALID {
+		return append([]T{}, in...)
+	}
+
+	out, _ := RemoveAt(in, index)
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// DeleteAllValues - returns a new slice without any occurrence of value.
+// -----------------------------------------------------------------------------
+func DeleteAllValues[T comparable](in []T, value T) []T {
+	return Filter(in, func(element T) bool { return element != value })
+}
+
+// -----------------------------------------------------------------------------
+// Prepend - returns a new slice with the values placed at the front,
+// in the given order. The input slice is left untouched.
+// -----------------------------------------------------------------------------
+func Prepend[T any](in []T, values ...T) []T {
+	out := make([]T, 0, len(values)+len(in))
+	out = append(out, values...)
+	return append(out, in...)
+}
+
+// -----------------------------------------------------------------------------
+// Append - returns a new slice with the values placed at the back.
+// Unlike the builtin append it never writes into the input backing array.
+// -----------------------------------------------------------------------------
+func Append[T any](in []T, values ...T) []T {
+	out := make([]T, 0, len(in)+len(values))
+	out = append(out, in...)
+	return append(out, values...)
+}
@@ -0,0 +1,61 @@
+package slices
+
+// -----------------------------------------------------------------------------
+// Pair - holds two values of possibly different types.
+// -----------------------------------------------------------------------------
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// -----------------------------------------------------------------------------
+// Zip - combines two slices into a slice of pairs.
+// Zipping stops at the shorter of the two slices.
+// -----------------------------------------------------------------------------
+func Zip[A, B any](as []A, bs []B) []Pair[A, B] {
+	out := make([]Pair[A, B], min(len(as), len(bs)))
+	for index := range out {
+		out[index] = Pair[A, B]{First: as[index], Second: bs[index]}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Unzip - splits a slice of pairs into the slices of first and second values.
+// Both results are freshly allocated with the length of the input.
+// -----------------------------------------------------------------------------
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for index, pair := range pairs {
+		as[index] = pair.First
+		bs[index] = pair.Second
+	}
+	return as, bs
+}
+
+// -----------------------------------------------------------------------------
+// Pairwise - returns the consecutive overlapping pairs of the slice.
+// A slice with fewer than two elements yields an empty result.
+// -----------------------------------------------------------------------------
+func Pairwise[T any](in []T) []Pair[T, T] {
+	out := make([]Pair[T, T], max(0, len(in)-1))
+	for index := range out {
+		out[index] = Pair[T, T]{First: in[index], Second: in[index+1]}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// CartesianProduct - returns every combination of one element from each
+// slice, with the elements of as varying slowest.
+// -----------------------------------------------------------------------------
+func CartesianProduct[A, B any](as []A, bs []B) []Pair[A, B] {
+	out := make([]Pair[A, B], 0, len(as)*len(bs))
+	for _, a := range as {
+		for _, b := range bs {
+			out = append(out, Pair[A, B]{First: a, Second: b})
+		}
+	}
+	return out
+}
@@ -0,0 +1,174 @@
+package slices
+
+import (
+	"errors"
+)
+
+// ErrorInvalidSize - returned when a requested size is not positive.
+var ErrorInvalidSize = errors.New("Size must be positive.")
+
+// -----------------------------------------------------------------------------
+// Chunk - partitions the slice into sub-slices of at most size elements.
+// The last chunk holds the remainder. Chunks share the input backing array.
+// -----------------------------------------------------------------------------
+func Chunk[T any](in []T, size int) ([][]T, error) {
+	if size <= 0 {
+		return nil, ErrorInvalidSize
+	}
+
+	out := make([][]T, 0, (len(in)+size-1)/size)
+	for start := 0; start < len(in); start += size {
+		end := min(start+size, len(in))
+		out = append(out, in[start:end:end])
+	}
+	return out, nil
+}
+
+// -----------------------------------------------------------------------------
+// GroupBy - buckets the elements by the key returned from the selector.
+// Elements keep their input order within each bucket.
+// -----------------------------------------------------------------------------
+func GroupBy[T any, K comparable](in []T, key func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, value := range in {
+		group := key(value)
+		out[group] = append(out[group], value)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Partition - splits the slice in a single pass into the elements satisfying
+// the predicate and the rest. Both results keep the input order.
+// -----------------------------------------------------------------------------
+func Partition[T any](in []T, pred func(T) bool) (matched []T, rest []T) {
+	matched, rest = []T{}, []T{}
+	for _, value := range in {
+		if pred(value) {
+			matched = append(matched, value)
+		} else {
+			rest = append(rest, value)
+		}
+	}
+	return matched, rest
+}
+
+// -----------------------------------------------------------------------------
+// Take - returns a copy of the first n elements, or of the whole slice
+// when n exceeds its length. A negative n is treated as zero.
+// -----------------------------------------------------------------------------
+func Take[T any](in []T, n int) []T {
+	n = max(0, min(n, len(in)))
+	return append([]T{}, in[:n]...)
+}
+
+// -----------------------------------------------------------------------------
+// Drop - returns a copy of the elements after the first n.
+// A negative n is treated as zero.
+// -----------------------------------------------------------------------------
+func Drop[T any](in []T, n int) []T {
+	n = max(0, min(n, len(in)))
+	return append([]T{}, in[n:]...)
+}
+
+// -----------------------------------------------------------------------------
+// TakeWhile - returns a copy of the leading elements satisfying the predicate.
+// Scanning stops at the first element that fails it.
+// -----------------------------------------------------------------------------
+func TakeWhile[T any](in []T, pred func(T) bool) []T {
+	return Take(in, leading(in, pred))
+}
+
+// -----------------------------------------------------------------------------
+// DropWhile - returns a copy of the slice after the leading elements
+// satisfying the predicate. Scanning stops at the first element that fails it.
+// -----------------------------------------------------------------------------
+func DropWhile[T any](in []T, pred func(T) bool) []T {
+	return Drop(in, leading(in, pred))
+}
+
+// -----------------------------------------------------------------------------
+// leading - returns the length of the leading run satisfying the predicate.
+// -----------------------------------------------------------------------------
+func leading[T any](in []T, pred func(T) bool) int {
+	for index, value := range in {
+		if !pred(value) {
+			return index
+		}
+	}
+	return len(in)
+}
+
+// -----------------------------------------------------------------------------
+// Window - returns every contiguous sub-slice of the given size, advancing
+// by one element. A slice shorter than size yields an empty result.
+// Windows share the input backing array.
+// -----------------------------------------------------------------------------
+func Window[T any](in []T, size int) ([][]T, error) {
+	if size <= 0 {
+		return nil, ErrorInvalidSize
+	}
+
+	out := make([][]T, 0, max(0, len(in)-size+1))
+	for start := 0; start+size <= len(in); start++ {
+		out = append(out, in[start:start+size:start+size])
+	}
+	return out, nil
+}
+
+// -----------------------------------------------------------------------------
+// Associate - builds a map by applying fn to each element to produce
+// a key/value pair. A later duplicate key overwrites the earlier value.
+// -----------------------------------------------------------------------------
+func Associate[T any, K comparable, V any](in []T, fn func(T) (K, V)) map[K]V {
+	out := make(map[K]V, len(in))
+	for _, value := range in {
+		key, entry := fn(value)
+		out[key] = entry
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Batch - invokes fn on successive chunks of at most size elements,
+// without materializing all chunks at once. The final partial batch
+// is delivered as well. Stops at and returns the first non-nil error.
+// -----------------------------------------------------------------------------
+func Batch[T any](in []T, size int, fn func(batch []T) error) error {
+	if size <= 0 {
+		return ErrorInvalidSize
+	}
+
+	for start := 0; start < len(in); start += size {
+		end := min(start+size, len(in))
+		if err := fn(in[start:end:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// ChunkInto - splits the slice into the given number of roughly equal parts,
+// handing the remainder out one element at a time to the earlier parts.
+// Parts share the input backing array and may be empty when there are
+// more groups than elements.
+// -----------------------------------------------------------------------------
+func ChunkInto[T any](in []T, groups int) ([][]T, error) {
+	if groups <= 0 {
+		return nil, ErrorInvalidSize
+	}
+
+	size, remainder := len(in)/groups, len(in)%groups
+	out := make([][]T, groups)
+	start := 0
+	for group := range out {
+		end := start + size
+		if group < remainder {
+			end++
+		}
+		out[group] = in[start:end:end]
+		start = end
+	}
+	return out, nil
+}
@@ -0,0 +1,52 @@
+package slices
+
+import (
+	"math/rand"
+)
+
+// -----------------------------------------------------------------------------
+// intn - returns a random number in [0, n) from the given source.
+// A nil source falls back to the randomly seeded package-level source.
+// -----------------------------------------------------------------------------
+func intn(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.Intn(n)
+	}
+	return rng.Intn(n)
+}
+
+// -----------------------------------------------------------------------------
+// Shuffle - performs an in-place Fisher-Yates shuffle using rng.
+// Seeding rng with a known value makes the shuffle reproducible.
+// A nil rng uses the package-level source and is therefore non-deterministic.
+// -----------------------------------------------------------------------------
+func Shuffle[T any](in []T, rng *rand.Rand) {
+	for index := len(in) - 1; index > 0; index-- {
+		other := intn(rng, index+1)
+		in[index], in[other] = in[other], in[index]
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Sample - returns n distinct elements chosen at random using rng.
+// Uses reservoir sampling, so the input is scanned once and left untouched.
+// When n is not smaller than the input a shuffled copy of it is returned.
+// -----------------------------------------------------------------------------
+func Sample[T any](in []T, n int, rng *rand.Rand) []T {
+	if n >= len(in) {
+		out := Clone(in)
+		Shuffle(out, rng)
+		return out
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	out := Clone(in[:n])
+	for index := n; index < len(in); index++ {
+		if slot := intn(rng, index+1); slot < n {
+			out[slot] = in[index]
+		}
+	}
+	return out
+}
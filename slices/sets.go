@@ -0,0 +1,70 @@
+package slices
+
+// -----------------------------------------------------------------------------
+// set - builds a lookup set out of the slice elements.
+// -----------------------------------------------------------------------------
+func set[T comparable](in []T) map[T]struct{} {
+	out := make(map[T]struct{}, len(in))
+	for _, value := range in {
+		out[value] = struct{}{}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Intersection - returns the deduplicated elements present in both slices,
+// in the order they appear in a.
+// -----------------------------------------------------------------------------
+func Intersection[T comparable](a, b []T) []T {
+	lookup := set(b)
+	seen := make(map[T]struct{})
+	out := []T{}
+	for _, value := range a {
+		if _, ok := lookup[value]; !ok {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Union - returns the deduplicated elements of both slices, in the order
+// they are first seen across a and then b.
+// -----------------------------------------------------------------------------
+func Union[T comparable](a, b []T) []T {
+	seen := make(map[T]struct{}, len(a)+len(b))
+	out := []T{}
+	for _, values := range [][]T{a, b} {
+		for _, value := range values {
+			if _, ok := seen[value]; ok {
+				continue
+			}
+			seen[value] = struct{}{}
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Difference - returns the deduplicated elements of a that are not in b,
+// in the order they appear in a.
+// -----------------------------------------------------------------------------
+func Difference[T comparable](a, b []T) []T {
+	// Excluding every emitted value as well removes duplicates from the result.
+	excluded := set(b)
+	out := []T{}
+	for _, value := range a {
+		if _, ok := excluded[value]; ok {
+			continue
+		}
+		excluded[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
@@ -16,3 +16,182 @@ func IndexOf(limit int, predicate func(i int) bool) int {
 	}
 	return INVALID
 }
+
+// -----------------------------------------------------------------------------
+// FindIndex - returns the index of the first element satisfying the predicate,
+// or INVALID if there is no such element.
+// -----------------------------------------------------------------------------
+func FindIndex[T any](in []T, pred func(T) bool) int {
+	for index, value := range in {
+		if pred(value) {
+			return index
+		}
+	}
+	return INVALID
+}
+
+// -----------------------------------------------------------------------------
+// LastIndexOf - returns the index of the last element satisfying
+// the predicate, or INVALID if there is no such element.
+// -----------------------------------------------------------------------------
+func LastIndexOf[T any](in []T, pred func(T) bool) int {
+	for index := len(in) - 1; index >= 0; index-- {
+		if pred(in[index]) {
+			return index
+		}
+	}
+	return INVALID
+}
+
+// -----------------------------------------------------------------------------
+// Find - returns the first element satisfying the predicate.
+// The boolean is false when there is no such element.
+// -----------------------------------------------------------------------------
+func Find[T any](in []T, pred func(T) bool) (T, bool) {
+	if index := FindIndex(in, pred); index != INVALID {
+		return in[index], true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// -----------------------------------------------------------------------------
+// FindLast - returns the last element satisfying the predicate.
+// The boolean is false when there is no such element.
+// -----------------------------------------------------------------------------
+func FindLast[T any](in []T, pred func(T) bool) (T, bool) {
+	if index := LastIndexOf(in, pred); index != INVALID {
+		return in[index], true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// -----------------------------------------------------------------------------
+// Contains - verifies if the target value is present in the slice.
+// -----------------------------------------------------------------------------
+func Contains[T comparable](in []T, target T) bool {
+	for _, value := range in {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// ContainsFunc - verifies if any element of the slice satisfies the predicate.
+// -----------------------------------------------------------------------------
+func ContainsFunc[T any](in []T, pred func(T) bool) bool {
+	for _, value := range in {
+		if pred(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// All - verifies if every element satisfies the predicate.
+// An empty slice yields true. Evaluation stops at the first element
+// for which the predicate returns false.
+// -----------------------------------------------------------------------------
+func All[T any](in []T, pred func(T) bool) bool {
+	for _, value := range in {
+		if !pred(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// Some - verifies if at least one element satisfies the predicate.
+// An empty slice yields false. Evaluation stops at the first element
+// for which the predicate returns true.
+// Named Some since Any is already taken by the interface type above.
+// -----------------------------------------------------------------------------
+func Some[T any](in []T, pred func(T) bool) bool {
+	for _, value := range in {
+		if pred(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// Equal - verifies if both slices have the same length and equal elements
+// at each position. Nil and empty slices are considered equal.
+// -----------------------------------------------------------------------------
+func Equal[T comparable](a, b []T) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// -----------------------------------------------------------------------------
+// EqualFunc - verifies if both slices have the same length and elements
+// at each position are equal according to eq.
+// -----------------------------------------------------------------------------
+func EqualFunc[T any](a, b []T, eq func(x, y T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for index := range a {
+		if !eq(a[index], b[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// Clone - returns a shallow copy backed by a fresh array.
+// A nil slice is returned as nil.
+// -----------------------------------------------------------------------------
+func Clone[T any](in []T) []T {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]T, len(in))
+	copy(out, in)
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// First - returns the first element.
+// The boolean is false when the slice is empty.
+// -----------------------------------------------------------------------------
+func First[T any](in []T) (T, bool) {
+	if len(in) == 0 {
+		var zero T
+		return zero, false
+	}
+	return in[0], true
+}
+
+// -----------------------------------------------------------------------------
+// Last - returns the last element.
+// The boolean is false when the slice is empty.
+// -----------------------------------------------------------------------------
+func Last[T any](in []T) (T, bool) {
+	if len(in) == 0 {
+		var zero T
+		return zero, false
+	}
+	return in[len(in)-1], true
+}
+
+// -----------------------------------------------------------------------------
+// ElementAtOr - returns the element at the given index,
+// or the fallback when the index is out of range.
+// -----------------------------------------------------------------------------
+func ElementAtOr[T any](in []T, index int, fallback T) T {
+	if index < 0 || index >= len(in) {
+		return fallback
+	}
+	return in[index]
+}
@@ -0,0 +1,237 @@
+package slices
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestChunkInto - The remainder goes one element at a time to earlier parts.
+// -----------------------------------------------------------------------------
+func TestChunkInto(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     []int
+		groups int
+		want   [][]int
+		err    error
+	}{
+		{"even", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}, nil},
+		{"remainder", []int{1, 2, 3, 4, 5, 6, 7}, 3, [][]int{{1, 2, 3}, {4, 5}, {6, 7}}, nil},
+		{"more groups", []int{1, 2}, 4, [][]int{{1}, {2}, {}, {}}, nil},
+		{"empty", []int{}, 2, [][]int{{}, {}}, nil},
+		{"zero groups", []int{1, 2}, 0, nil, ErrorInvalidSize},
+		{"negative groups", []int{1, 2}, -1, nil, ErrorInvalidSize},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ChunkInto(test.in, test.groups)
+			if err != test.err {
+				t.Fatalf("got error %v, expected %v", err, test.err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestRotate - Negative and oversized shifts wrap around the slice length.
+// -----------------------------------------------------------------------------
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		n    int
+		want []int
+	}{
+		{"zero", []int{1, 2, 3, 4}, 0, []int{1, 2, 3, 4}},
+		{"left", []int{1, 2, 3, 4}, 1, []int{2, 3, 4, 1}},
+		{"right", []int{1, 2, 3, 4}, -1, []int{4, 1, 2, 3}},
+		{"full turn", []int{1, 2, 3, 4}, 4, []int{1, 2, 3, 4}},
+		{"large", []int{1, 2, 3, 4}, 10, []int{3, 4, 1, 2}},
+		{"large negative", []int{1, 2, 3, 4}, -10, []int{3, 4, 1, 2}},
+		{"empty", []int{}, 3, []int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Rotate(test.in, test.n); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestCombinations - A zero k yields one empty combination and a k out of
+// range yields none.
+// -----------------------------------------------------------------------------
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		k    int
+		want [][]int
+	}{
+		{"zero", []int{1, 2, 3}, 0, [][]int{{}}},
+		{"one", []int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{"two", []int{1, 2, 3}, 2, [][]int{{1, 2}, {1, 3}, {2, 3}}},
+		{"all", []int{1, 2, 3}, 3, [][]int{{1, 2, 3}}},
+		{"too large", []int{1, 2, 3}, 4, [][]int{}},
+		{"negative", []int{1, 2, 3}, -1, [][]int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Combinations(test.in, test.k); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestShuffle - A seeded source makes the shuffle reproducible and keeps
+// every element.
+// -----------------------------------------------------------------------------
+func TestShuffle(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+	}{
+		{"empty", []int{}},
+		{"single", []int{1}},
+		{"many", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, second := Clone(test.in), Clone(test.in)
+			Shuffle(first, rand.New(rand.NewSource(42)))
+			Shuffle(second, rand.New(rand.NewSource(42)))
+			if !reflect.DeepEqual(first, second) {
+				t.Fatalf("got %v and %v from the same seed", first, second)
+			}
+
+			sort.Ints(first)
+			if !reflect.DeepEqual(first, test.in) {
+				t.Fatalf("got %v, expected a permutation of %v", first, test.in)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestSample - A seeded source makes the sample reproducible, and the sample
+// holds min(n, len) distinct input elements.
+// -----------------------------------------------------------------------------
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		n    int
+		want int
+	}{
+		{"zero", []int{1, 2, 3, 4, 5}, 0, 0},
+		{"negative", []int{1, 2, 3, 4, 5}, -1, 0},
+		{"some", []int{1, 2, 3, 4, 5}, 3, 3},
+		{"all", []int{1, 2, 3, 4, 5}, 5, 5},
+		{"too many", []int{1, 2, 3, 4, 5}, 8, 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first := Sample(test.in, test.n, rand.New(rand.NewSource(7)))
+			second := Sample(test.in, test.n, rand.New(rand.NewSource(7)))
+			if !reflect.DeepEqual(first, second) {
+				t.Fatalf("got %v and %v from the same seed", first, second)
+			}
+			if len(first) != test.want {
+				t.Fatalf("got %d elements, expected %d", len(first), test.want)
+			}
+
+			seen := map[int]bool{}
+			for _, value := range first {
+				if seen[value] || value < 1 || value > len(test.in) {
+					t.Fatalf("got %v, expected distinct elements of %v", first, test.in)
+				}
+				seen[value] = true
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestBinarySearchFunc - A missing target reports its insertion index.
+// -----------------------------------------------------------------------------
+func TestBinarySearchFunc(t *testing.T) {
+	compare := func(element, target int) int { return element - target }
+	in := []int{10, 20, 20, 30}
+
+	tests := []struct {
+		name   string
+		target int
+		index  int
+		found  bool
+	}{
+		{"before all", 5, 0, false},
+		{"first", 10, 0, true},
+		{"duplicate", 20, 1, true},
+		{"between", 25, 3, false},
+		{"last", 30, 3, true},
+		{"after all", 35, 4, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			index, found := BinarySearchFunc(in, test.target, compare)
+			if index != test.index || found != test.found {
+				t.Fatalf("got (%d, %t), expected (%d, %t)", index, found, test.index, test.found)
+			}
+		})
+	}
+
+	if index, found := BinarySearchFunc([]int{}, 1, compare); index != 0 || found {
+		t.Fatalf("got (%d, %t) on an empty slice, expected (0, false)", index, found)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestRemoveRange - Out of range and reversed bounds are reported as errors.
+// -----------------------------------------------------------------------------
+func TestRemoveRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		want       []int
+		err        error
+	}{
+		{"middle", 1, 3, []int{1, 4, 5}, nil},
+		{"empty range", 2, 2, []int{1, 2, 3, 4, 5}, nil},
+		{"everything", 0, 5, []int{}, nil},
+		{"negative start", -1, 2, nil, ErrorIndexOutOfRange},
+		{"end past length", 3, 6, nil, ErrorIndexOutOfRange},
+		{"reversed", 3, 1, nil, ErrorInvalidRange},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := []int{1, 2, 3, 4, 5}
+			got, err := RemoveRange(in, test.start, test.end)
+			if err != test.err {
+				t.Fatalf("got error %v, expected %v", err, test.err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, expected %v", got, test.want)
+			}
+			if !reflect.DeepEqual(in, []int{1, 2, 3, 4, 5}) {
+				t.Fatalf("input modified to %v", in)
+			}
+		})
+	}
+}
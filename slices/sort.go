@@ -0,0 +1,57 @@
+package slices
+
+import (
+	"cmp"
+	"sort"
+)
+
+// -----------------------------------------------------------------------------
+// SortBy - sorts the slice in place by the key extracted from each element.
+// The key function is called on every comparison, so it should be cheap.
+// -----------------------------------------------------------------------------
+func SortBy[T any, K cmp.Ordered](in []T, key func(T) K) {
+	sort.Slice(in, func(i, j int) bool {
+		return key(in[i]) < key(in[j])
+	})
+}
+
+// -----------------------------------------------------------------------------
+// SortByStable - sorts the slice in place by the extracted key,
+// keeping elements with equal keys in their original order.
+// -----------------------------------------------------------------------------
+func SortByStable[T any, K cmp.Ordered](in []T, key func(T) K) {
+	sort.SliceStable(in, func(i, j int) bool {
+		return key(in[i]) < key(in[j])
+	})
+}
+
+// -----------------------------------------------------------------------------
+// BinarySearch - searches for target in a slice sorted in ascending order.
+// Returns the index of target and true when present, or the index where
+// it would be inserted and false otherwise.
+// -----------------------------------------------------------------------------
+func BinarySearch[T cmp.Ordered](in []T, target T) (int, bool) {
+	return BinarySearchFunc(in, target, cmp.Compare[T])
+}
+
+// -----------------------------------------------------------------------------
+// BinarySearchFunc - works like BinarySearch on a slice sorted according
+// to compare, which returns a negative number, zero or a positive number
+// when the element orders before, equal to or after the target.
+// -----------------------------------------------------------------------------
+func BinarySearchFunc[T, E any](in []T, target E, compare func(T, E) int) (int, bool) {
+	index := sort.Search(len(in), func(i int) bool {
+		return compare(in[i], target) >= 0
+	})
+	return index, index < len(in) && compare(in[index], target) == 0
+}
+
+// -----------------------------------------------------------------------------
+// InsertSorted - returns a new slice with value inserted at the position
+// that keeps an ascending slice sorted.
+// -----------------------------------------------------------------------------
+func InsertSorted[T cmp.Ordered](in []T, value T) []T {
+	index, _ := BinarySearch(in, value)
+	out, _ := InsertAt(in, index, value)
+	return out
+}
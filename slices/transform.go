@@ -0,0 +1,262 @@
+package slices
+
+// -----------------------------------------------------------------------------
+// Map - applies transform to every element and returns the results
+// in a new slice of the same length, preserving the order.
+// The input slice is never modified.
+// -----------------------------------------------------------------------------
+func Map[T, U any](in []T, transform func(T) U) []U {
+	return MapIndexed(in, func(_ int, value T) U { return transform(value) })
+}
+
+// -----------------------------------------------------------------------------
+// MapIndexed - works like Map, but also passes the element index
+// to the transform.
+// -----------------------------------------------------------------------------
+func MapIndexed[T, U any](in []T, transform func(index int, value T) U) []U {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]U, len(in))
+	for index, value := range in {
+		out[index] = transform(index, value)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Filter - returns a new slice with the elements for which keep returns true.
+// The result is never nil and grows on demand instead of reserving
+// the capacity of the whole input.
+// -----------------------------------------------------------------------------
+func Filter[T any](in []T, keep func(T) bool) []T {
+	return FilterIndexed(in, func(_ int, value T) bool { return keep(value) })
+}
+
+// -----------------------------------------------------------------------------
+// FilterIndexed - works like Filter, but also passes the element index
+// to the predicate.
+// -----------------------------------------------------------------------------
+func FilterIndexed[T any](in []T, keep func(index int, value T) bool) []T {
+	out := []T{}
+	for index, value := range in {
+		if keep(index, value) {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Reduce - folds the slice into a single value, threading the accumulator
+// from left to right. An empty slice returns the initial value.
+// -----------------------------------------------------------------------------
+func Reduce[T, A any](in []T, initial A, acc func(A, T) A) A {
+	result := initial
+	for _, value := range in {
+		result = acc(result, value)
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// ReduceRight - folds the slice into a single value, threading the accumulator
+// from right to left. An empty slice returns the initial value.
+// -----------------------------------------------------------------------------
+func ReduceRight[T, A any](in []T, initial A, acc func(A, T) A) A {
+	result := initial
+	for index := len(in) - 1; index >= 0; index-- {
+		result = acc(result, in[index])
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// ForEach - calls fn for every element in order, passing its index and value.
+// -----------------------------------------------------------------------------
+func ForEach[T any](in []T, fn func(index int, value T)) {
+	for index, value := range in {
+		fn(index, value)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Reverse - returns a new slice with the elements in reverse order.
+// The input slice is left untouched.
+// -----------------------------------------------------------------------------
+func Reverse[T any](in []T) []T {
+	out := make([]T, len(in))
+	for index, value := range in {
+		out[len(in)-1-index] = value
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// ReverseInPlace - reverses the order of the elements without allocating.
+// -----------------------------------------------------------------------------
+func ReverseInPlace[T any](in []T) {
+	for left, right := 0, len(in)-1; left < right; left, right = left+1, right-1 {
+		in[left], in[right] = in[right], in[left]
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Unique - returns a new slice without duplicates, keeping the first
+// occurrence of each value and preserving the order.
+// -----------------------------------------------------------------------------
+func Unique[T comparable](in []T) []T {
+	seen := make(map[T]struct{}, len(in))
+	out := []T{}
+	for _, value := range in {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Flatten - concatenates all inner slices into a single slice, in order.
+// The total length is computed up front so only one allocation is made.
+// -----------------------------------------------------------------------------
+func Flatten[T any](in [][]T) []T {
+	total := 0
+	for _, inner := range in {
+		total += len(inner)
+	}
+
+	out := make([]T, 0, total)
+	for _, inner := range in {
+		out = append(out, inner...)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Rotate - returns a new slice rotated left by n positions.
+// A negative n rotates right, and n wraps around the length of the slice.
+// -----------------------------------------------------------------------------
+func Rotate[T any](in []T, n int) []T {
+	out := make([]T, len(in))
+	if len(in) == 0 {
+		return out
+	}
+
+	n %= len(in)
+	if n < 0 {
+		n += len(in)
+	}
+	copy(out, in[n:])
+	copy(out[len(in)-n:], in[:n])
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// FlatMap - applies fn to each element and concatenates the resulting slices,
+// without building the intermediate slice of slices.
+// -----------------------------------------------------------------------------
+func FlatMap[T, U any](in []T, fn func(T) []U) []U {
+	out := []U{}
+	for _, value := range in {
+		out = append(out, fn(value)...)
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Compact - returns a new slice without the zero-valued elements,
+// preserving the order.
+// -----------------------------------------------------------------------------
+func Compact[T comparable](in []T) []T {
+	var zero T
+	return Filter(in, func(value T) bool { return value != zero })
+}
+
+// -----------------------------------------------------------------------------
+// Scan - returns the running accumulator values, one per element,
+// each taken after the element has been folded in.
+// -----------------------------------------------------------------------------
+func Scan[T, A any](in []T, initial A, acc func(A, T) A) []A {
+	out := make([]A, len(in))
+	result := initial
+	for index, value := range in {
+		result = acc(result, value)
+		out[index] = result
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// ReplaceAll - returns a new slice with every element equal to old
+// replaced by new, preserving the order and length.
+// -----------------------------------------------------------------------------
+func ReplaceAll[T comparable](in []T, old, new T) []T {
+	out := make([]T, len(in))
+	for index, value := range in {
+		if value == old {
+			value = new
+		}
+		out[index] = value
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Transpose - swaps the rows and columns of a 2D slice.
+// Ragged rows are padded with zero values, so the result has as many
+// rows as the longest input row and as many columns as there are input rows.
+// -----------------------------------------------------------------------------
+func Transpose[T any](in [][]T) [][]T {
+	columns := 0
+	for _, row := range in {
+		columns = max(columns, len(row))
+	}
+
+	out := make([][]T, columns)
+	for column := range out {
+		out[column] = make([]T, len(in))
+		for index, row := range in {
+			if column < len(row) {
+				out[column][index] = row[column]
+			}
+		}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Interleave - takes elements round-robin from each slice until all of them
+// are exhausted, skipping the slices that run out early.
+// -----------------------------------------------------------------------------
+func Interleave[T any](slices ...[]T) []T {
+	total, longest := 0, 0
+	for _, slice := range slices {
+		total += len(slice)
+		longest = max(longest, len(slice))
+	}
+
+	out := make([]T, 0, total)
+	for index := 0; index < longest; index++ {
+		for _, slice := range slices {
+			if index < len(slice) {
+				out = append(out, slice[index])
+			}
+		}
+	}
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// DedupConsecutive - returns a new slice where runs of adjacent equal
+// elements are collapsed into one. Unlike Unique, a value that recurs
+// later after a different value is kept.
+// -----------------------------------------------------------------------------
+func DedupConsecutive[T comparable](in []T) []T {
+	return FilterIndexed(in, func(index int, value T) bool {
+		return index == 0 || in[index-1] != value
+	})
+}